@@ -0,0 +1,108 @@
+package main
+
+import (
+	"regexp"
+	"sync"
+	"time"
+)
+
+// ackRegex matches an APRS ACK message body, e.g. "ack123".
+var ackRegex = regexp.MustCompile(`(?i)^ack([A-Za-z0-9]+)$`)
+
+// ackRetrySchedule is the delay before each retransmission attempt. A
+// message that goes unacked past the last entry is marked failed.
+var ackRetrySchedule = []time.Duration{30 * time.Second, 60 * time.Second, 120 * time.Second}
+
+// pendingACK tracks one outbound message awaiting a matching "ackNNNNN"
+// reply.
+type pendingACK struct {
+	toCallsign  string
+	messageID   string
+	content     string
+	dbMessageID string
+	attempts    int
+	lastSent    time.Time
+}
+
+// ackTracker records outbound messages that requested an ACK and retries
+// them on a schedule until acked or exhausted.
+type ackTracker struct {
+	mu      sync.Mutex
+	pending map[string]*pendingACK
+}
+
+func newAckTracker() *ackTracker {
+	return &ackTracker{pending: make(map[string]*pendingACK)}
+}
+
+// Len returns the number of sends currently awaiting an ACK.
+func (t *ackTracker) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.pending)
+}
+
+func ackKey(toCallsign, messageID string) string {
+	return toCallsign + "/" + messageID
+}
+
+// Track begins watching for an ACK from toCallsign matching messageID.
+// dbMessageID is the messages collection record this send was for, so the
+// caller can mark it delivered once the ACK arrives.
+func (t *ackTracker) Track(toCallsign, messageID, content, dbMessageID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending[ackKey(toCallsign, messageID)] = &pendingACK{
+		toCallsign:  toCallsign,
+		messageID:   messageID,
+		content:     content,
+		dbMessageID: dbMessageID,
+		attempts:    1,
+		lastSent:    time.Now(),
+	}
+}
+
+// Ack records that fromCallsign acked messageID, removing it from tracking.
+// It returns the tracked entry and whether one was found.
+func (t *ackTracker) Ack(fromCallsign, messageID string) (pendingACK, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := ackKey(fromCallsign, messageID)
+	p, ok := t.pending[key]
+	if !ok {
+		return pendingACK{}, false
+	}
+	delete(t.pending, key)
+	return *p, true
+}
+
+// DueForRetry returns a snapshot of pending sends whose retry delay has
+// elapsed, bumping their attempt count and lastSent time. Entries that have
+// exhausted ackRetrySchedule are returned separately as expired and removed
+// from tracking.
+func (t *ackTracker) DueForRetry() (retry []pendingACK, expired []pendingACK) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	for key, p := range t.pending {
+		if p.attempts > len(ackRetrySchedule) {
+			expired = append(expired, *p)
+			delete(t.pending, key)
+			continue
+		}
+
+		delay := ackRetrySchedule[p.attempts-1]
+		if now.Sub(p.lastSent) < delay {
+			continue
+		}
+
+		p.attempts++
+		p.lastSent = now
+		retry = append(retry, *p)
+	}
+
+	return retry, expired
+}
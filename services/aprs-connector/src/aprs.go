@@ -2,10 +2,12 @@ package main
 
 import (
 	"bufio"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -23,12 +25,49 @@ type APRSMessage struct {
 
 // APRSClient manages the APRS-IS connection
 type APRSClient struct {
-	config      *Config
-	logger      *logrus.Logger
-	conn        net.Conn
-	connected   bool
-	stopChannel chan bool
-	db          *DatabaseClient
+	configMu     sync.RWMutex // guards config against concurrent reads and an OnReload swap
+	config       *Config
+	logger       *logrus.Logger
+	connMu       sync.Mutex // guards conn and connected against concurrent writers/Disconnect
+	conn         net.Conn
+	connected    bool
+	stopChannel  chan bool
+	db           *DatabaseClient
+	pool         *EndpointPool
+	current      *APRSEndpoint
+	lastActivity time.Time
+	acks         *ackTracker
+}
+
+// touchActivity records that a packet was just sent or received, guarded
+// by connMu since it's read concurrently by runWatchdog and RunHealthCheck.
+func (a *APRSClient) touchActivity() {
+	a.connMu.Lock()
+	a.lastActivity = time.Now()
+	a.connMu.Unlock()
+}
+
+// LastActivity returns the time of the last sent or received packet, safe
+// to call concurrently with Listen()'s read loop.
+func (a *APRSClient) LastActivity() time.Time {
+	a.connMu.Lock()
+	defer a.connMu.Unlock()
+	return a.lastActivity
+}
+
+// Config returns the client's current configuration, safe to call
+// concurrently with a reload swapping it out.
+func (a *APRSClient) Config() *Config {
+	a.configMu.RLock()
+	defer a.configMu.RUnlock()
+	return a.config
+}
+
+// SetConfig swaps in a new configuration, e.g. after a SIGHUP reload.
+func (a *APRSClient) SetConfig(config *Config) {
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+	a.config = config
 }
 
 // APRS message parsing regex patterns
@@ -39,54 +78,88 @@ var (
 
 // NewAPRSClient creates a new APRS client
 func NewAPRSClient(config *Config, logger *logrus.Logger, db *DatabaseClient) *APRSClient {
+	cooldown := time.Duration(config.APRS.EndpointPool.CooldownSeconds) * time.Second
 	return &APRSClient{
 		config:      config,
 		logger:      logger,
 		db:          db,
 		stopChannel: make(chan bool),
+		pool:        NewEndpointPool(config.APRS.Endpoints, cooldown),
+		acks:        newAckTracker(),
 	}
 }
 
-// Connect establishes connection to APRS-IS
+// Connect establishes connection to the next healthy endpoint in the pool
 func (a *APRSClient) Connect() error {
+	endpoint := a.pool.Next()
+	if endpoint == nil {
+		return fmt.Errorf("no APRS-IS endpoints configured")
+	}
+	a.current = endpoint
+
+	passcode := endpoint.Passcode
+	if passcode == "" {
+		passcode = a.Config().APRS.Passcode
+	}
+
+	timeout := endpoint.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
 	a.logger.WithFields(logrus.Fields{
-		"server":   a.config.APRS.Server,
-		"port":     a.config.APRS.Port,
-		"callsign": a.config.APRS.Callsign,
+		"server":   endpoint.Host,
+		"port":     endpoint.Port,
+		"callsign": a.Config().APRS.Callsign,
 	}).Info("Connecting to APRS-IS")
 
-	conn, err := net.DialTimeout("tcp",
-		fmt.Sprintf("%s:%d", a.config.APRS.Server, a.config.APRS.Port),
-		30*time.Second)
+	var conn net.Conn
+	var err error
+	if a.Config().APRS.TLS {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", endpoint.Addr(),
+			&tls.Config{ServerName: endpoint.Host})
+	} else {
+		conn, err = net.DialTimeout("tcp", endpoint.Addr(), timeout)
+	}
 	if err != nil {
+		a.pool.MarkFailed(*endpoint)
 		return fmt.Errorf("failed to connect to APRS-IS: %w", err)
 	}
 
+	a.connMu.Lock()
 	a.conn = conn
 	a.connected = true
+	a.stopChannel = make(chan bool) // fresh stop signal for this connection's lifetime
+	a.connMu.Unlock()
+	a.touchActivity()
 
 	// Send login string
 	loginString := fmt.Sprintf("user %s pass %s vers RARSMS 1.0 filter %s\r\n",
-		a.config.APRS.Callsign,
-		a.config.APRS.Passcode,
-		a.config.APRS.Filter)
+		a.Config().APRS.Callsign,
+		passcode,
+		a.Config().BuildFilter())
 
-	if _, err := a.conn.Write([]byte(loginString)); err != nil {
+	if err := a.writeLine(loginString); err != nil {
+		a.connMu.Lock()
 		a.conn.Close()
 		a.connected = false
+		a.connMu.Unlock()
+		a.pool.MarkFailed(*endpoint)
 		return fmt.Errorf("failed to send login: %w", err)
 	}
 
+	a.pool.MarkHealthy(*endpoint)
 	a.logger.Info("Successfully connected to APRS-IS")
 
 	// Update system status
 	if err := a.db.UpdateSystemStatus("aprs-connector", "online", map[string]interface{}{
-		"server":     a.config.APRS.Server,
-		"port":       a.config.APRS.Port,
-		"callsign":   a.config.APRS.Callsign,
-		"filter":     a.config.APRS.Filter,
-		"connected":  true,
+		"server":       endpoint.Host,
+		"port":         endpoint.Port,
+		"callsign":     a.Config().APRS.Callsign,
+		"filter":       a.Config().APRS.Filter,
+		"connected":    true,
 		"connect_time": time.Now().Unix(),
+		"endpoints":    a.pool.Status(),
 	}); err != nil {
 		a.logger.WithError(err).Warn("Failed to update system status")
 	}
@@ -98,17 +171,21 @@ func (a *APRSClient) Connect() error {
 func (a *APRSClient) Disconnect() error {
 	a.logger.Info("Disconnecting from APRS-IS")
 
-	// Signal stop to goroutines
-	close(a.stopChannel)
-
+	a.connMu.Lock()
+	// Signal stop to goroutines spawned for this connection (heartbeat, watchdog)
+	if a.stopChannel != nil {
+		close(a.stopChannel)
+		a.stopChannel = nil
+	}
 	if a.conn != nil {
 		a.conn.Close()
 	}
 	a.connected = false
+	a.connMu.Unlock()
 
 	// Update system status
 	if err := a.db.UpdateSystemStatus("aprs-connector", "offline", map[string]interface{}{
-		"connected":     false,
+		"connected":        false,
 		"disconnect_time": time.Now().Unix(),
 	}); err != nil {
 		a.logger.WithError(err).Warn("Failed to update system status")
@@ -119,23 +196,54 @@ func (a *APRSClient) Disconnect() error {
 
 // IsConnected returns the connection status
 func (a *APRSClient) IsConnected() bool {
+	a.connMu.Lock()
+	defer a.connMu.Unlock()
 	return a.connected
 }
 
-// Listen starts listening for APRS messages
+// writeLine writes a line to the current connection if one is open,
+// guarding against a concurrent Disconnect closing it out from under us.
+func (a *APRSClient) writeLine(line string) error {
+	a.connMu.Lock()
+	conn, connected := a.conn, a.connected
+	a.connMu.Unlock()
+
+	if !connected || conn == nil {
+		return fmt.Errorf("not connected to APRS-IS")
+	}
+	_, err := conn.Write([]byte(line))
+	return err
+}
+
+// Listen starts listening for APRS messages. A watchdog runs alongside it
+// and forces a reconnect if no traffic (including the "#" keepalives
+// APRS-IS servers send roughly every 20s) has arrived within the configured
+// watchdog window.
 func (a *APRSClient) Listen() error {
-	if !a.connected {
+	a.connMu.Lock()
+	conn, stopChannel, connected := a.conn, a.stopChannel, a.connected
+	a.connMu.Unlock()
+
+	if !connected {
 		return fmt.Errorf("not connected to APRS-IS")
 	}
 
 	a.logger.Info("Starting APRS message listener")
 
-	scanner := bufio.NewScanner(a.conn)
+	watchdogTimeout := time.Duration(a.Config().APRS.WatchdogSeconds) * time.Second
+	if watchdogTimeout <= 0 {
+		watchdogTimeout = 90 * time.Second
+	}
+	watchdogDone := make(chan struct{})
+	defer close(watchdogDone)
+	go a.runWatchdog(conn, stopChannel, watchdogDone, watchdogTimeout)
+
+	scanner := bufio.NewScanner(conn)
 	scanner.Buffer(make([]byte, 1024), 8192) // Increase buffer size for long packets
 
 	for scanner.Scan() {
 		select {
-		case <-a.stopChannel:
+		case <-stopChannel:
 			a.logger.Info("APRS listener stopped")
 			return nil
 		default:
@@ -144,6 +252,8 @@ func (a *APRSClient) Listen() error {
 				continue
 			}
 
+			a.touchActivity()
+
 			// Log raw packet for debugging
 			a.logger.WithField("packet", line).Debug("Received APRS packet")
 
@@ -176,13 +286,16 @@ func (a *APRSClient) handleAPRSPacket(rawPacket string) error {
 	// Parse APRS message
 	message, err := a.parseAPRSMessage(rawPacket)
 	if err != nil {
-		// Not all packets are messages, so this is not always an error
-		a.logger.WithField("packet", rawPacket).Debug("Packet is not a message")
+		// Not a text message; see if it's one of the other common packet
+		// types (position, weather, telemetry, object, status) instead.
+		if routeErr := a.routeNonMessagePacket(rawPacket); routeErr != nil {
+			a.logger.WithField("packet", rawPacket).Debug("Packet did not match any known type")
+		}
 		return nil
 	}
 
 	// Check if message is addressed to our callsign
-	if strings.ToUpper(message.ToCallsign) != strings.ToUpper(a.config.APRS.Callsign) {
+	if strings.ToUpper(message.ToCallsign) != strings.ToUpper(a.Config().APRS.Callsign) {
 		return nil
 	}
 
@@ -193,14 +306,37 @@ func (a *APRSClient) handleAPRSPacket(rawPacket string) error {
 		"message_id": message.MessageID,
 	}).Info("Received APRS message for RARSMS")
 
-	// Validate sender is authorized
-	isAuthorized, err := a.db.IsAuthorizedMember(message.FromCallsign)
-	if err != nil {
-		a.logger.WithError(err).WithField("callsign", message.FromCallsign).Error("Failed to check authorization")
-		return err
+	// An ACK of one of our own outbound sends; stop retrying it and mark
+	// the originating message delivered, but don't route or ACK it ourselves.
+	if ackMatch := ackRegex.FindStringSubmatch(message.Content); ackMatch != nil {
+		a.handleIncomingAck(message.FromCallsign, ackMatch[1])
+		return nil
+	}
+
+	// A path rule can restrict which message types a callsign may send, or
+	// opt it out of the member-authorization check entirely (e.g. a trusted
+	// digipeater path that doesn't have a PocketBase member profile).
+	pathCfg, hasPath := a.Config().PathFor(message.FromCallsign)
+	if hasPath && !pathCfg.allows("message", "") {
+		a.logger.WithField("callsign", message.FromCallsign).Warn("Message type not allowed by path configuration")
+		return nil
+	}
+
+	// Validate sender is authorized, unless its path explicitly opts out of
+	// the member-authorization check.
+	needsDBCheck := !hasPath || !pathCfg.SkipMemberAuth
+	isAuthorized := true
+	if needsDBCheck {
+		var err error
+		isAuthorized, err = a.db.IsAuthorizedMember(message.FromCallsign)
+		if err != nil {
+			a.logger.WithError(err).WithField("callsign", message.FromCallsign).Error("Failed to check authorization")
+			return err
+		}
 	}
 
 	if !isAuthorized {
+		metrics.authDenials.Inc()
 		a.logger.WithField("callsign", message.FromCallsign).Warn("Unauthorized callsign attempted to send message")
 
 		// Send ACK if message has ID (standard practice)
@@ -266,44 +402,124 @@ func (a *APRSClient) parseAPRSMessage(rawPacket string) (*APRSMessage, error) {
 
 // storeRawPacket stores the raw APRS packet for debugging
 func (a *APRSClient) storeRawPacket(rawPacket string) error {
-	packet := map[string]interface{}{
-		"raw_packet":       rawPacket,
-		"packet_type":      "other", // Will be updated if it's a message
-		"processed":        false,
-		"processing_notes": "",
+	packet := APRSPacket{
+		RawPacket:  rawPacket,
+		PacketType: "other", // Will be updated below once classified
+		Processed:  false,
 	}
 
 	// Try to extract basic info for indexing
 	if strings.Contains(rawPacket, "::") {
-		packet["packet_type"] = "message"
+		packet.PacketType = "message"
 
 		// Try to extract callsigns
 		if matches := messageRegex.FindStringSubmatch(rawPacket); len(matches) >= 4 {
-			packet["from_callsign"] = strings.ToUpper(matches[1])
-			packet["to_callsign"] = strings.ToUpper(matches[3])
+			packet.FromCallsign = strings.ToUpper(matches[1])
+			packet.ToCallsign = strings.ToUpper(matches[3])
+		}
+	} else if _, packetType, err := classifyAndParse(rawPacket); err == nil {
+		packet.PacketType = packetType
+		if from, _, _, ok := splitPacketHeader(rawPacket); ok {
+			packet.FromCallsign = from
 		}
 	}
 
+	metrics.packetsReceived.WithLabelValues(packet.PacketType).Inc()
+	events.publish("packet", packet)
+
 	return a.db.CreateAPRSPacket(packet)
 }
 
+// routeNonMessagePacket decodes packet types other than text messages
+// (positions, weather, MIC-E, telemetry, objects/items, status) and writes
+// them into their dedicated collections so the bridge can route non-message
+// traffic to Discord embeds and other downstream consumers.
+func (a *APRSClient) routeNonMessagePacket(rawPacket string) error {
+	parsed, packetType, err := classifyAndParse(rawPacket)
+	if err != nil {
+		return err
+	}
+
+	switch v := parsed.(type) {
+	case *PositionReport:
+		return a.db.CreatePosition(PositionRecord{
+			FromCallsign: v.FromCallsign,
+			Latitude:     v.Latitude,
+			Longitude:    v.Longitude,
+			SymbolTable:  string(v.SymbolTable),
+			SymbolCode:   string(v.SymbolCode),
+			Comment:      v.Comment,
+			Compressed:   v.Compressed,
+			MicE:         v.MicE,
+			RawPacket:    rawPacket,
+		})
+	case *WeatherReport:
+		return a.db.CreateWeatherReport(WeatherRecord{
+			FromCallsign:      v.FromCallsign,
+			WindDirection:     v.WindDirection,
+			WindSpeedMPH:      v.WindSpeedMPH,
+			GustMPH:           v.GustMPH,
+			TempF:             v.TempF,
+			RainLastHour:      v.RainLastHour,
+			RainLast24h:       v.RainLast24h,
+			RainSinceMidnight: v.RainSinceMid,
+			Humidity:          v.Humidity,
+			PressureTenths:    v.PressureTenth,
+			RawPacket:         rawPacket,
+		})
+	case *Telemetry:
+		return a.db.CreateTelemetry(TelemetryRecord{
+			FromCallsign: v.FromCallsign,
+			Sequence:     v.Sequence,
+			Analog:       v.Analog,
+			Digital:      v.Digital,
+			RawPacket:    rawPacket,
+		})
+	case *Object:
+		return a.db.CreateObject(ObjectRecord{
+			FromCallsign: v.FromCallsign,
+			Name:         v.Name,
+			Live:         v.Live,
+			Latitude:     v.Latitude,
+			Longitude:    v.Longitude,
+			SymbolTable:  string(v.SymbolTable),
+			SymbolCode:   string(v.SymbolCode),
+			Comment:      v.Comment,
+			RawPacket:    rawPacket,
+		})
+	case *Status:
+		return a.db.CreateStatus(StatusRecord{
+			FromCallsign: v.FromCallsign,
+			Text:         v.Text,
+			RawPacket:    rawPacket,
+		})
+	default:
+		return fmt.Errorf("unhandled parsed packet type %q", packetType)
+	}
+}
+
 // storeMessage stores a parsed message for routing
 func (a *APRSClient) storeMessage(message *APRSMessage) error {
 	// Generate correlation ID for message tracking
 	correlationID := generateCorrelationID()
 
-	messageData := map[string]interface{}{
-		"correlation_id":  correlationID,
-		"from_callsign":   message.FromCallsign,
-		"from_service":    "aprs",
-		"to_service":      "discord", // Phase 1: always route to Discord
-		"content":         message.Content,
-		"message_type":    "message",
-		"status":          "pending",
-		"metadata": map[string]interface{}{
+	routes := a.Config().Services.Routing.DestinationsFor("aprs")
+	if pathCfg, ok := a.Config().PathFor(message.FromCallsign); ok && len(pathCfg.AllowedDestinations) > 0 {
+		routes = filterAllowed(routes, pathCfg.AllowedDestinations)
+	}
+
+	messageData := Message{
+		CorrelationID: correlationID,
+		FromCallsign:  message.FromCallsign,
+		FromService:   "aprs",
+		Routes:        routes,
+		Content:       message.Content,
+		MessageType:   "message",
+		Status:        "pending",
+		Metadata: map[string]interface{}{
 			"aprs_message_id": message.MessageID,
 			"raw_packet":      message.RawPacket,
-			"server":          a.config.APRS.Server,
+			"server":          a.Config().APRS.Server,
 		},
 	}
 
@@ -312,13 +528,18 @@ func (a *APRSClient) storeMessage(message *APRSMessage) error {
 	if err != nil {
 		a.logger.WithError(err).WithField("callsign", message.FromCallsign).Warn("Failed to get user ID")
 	} else if userID != "" {
-		messageData["user"] = userID
+		messageData.User = userID
 	}
 
 	if err := a.db.CreateMessage(messageData); err != nil {
 		return fmt.Errorf("failed to store message: %w", err)
 	}
 
+	for _, dest := range routes {
+		metrics.messagesRouted.WithLabelValues("aprs", dest).Inc()
+	}
+	events.publish("message_routed", messageData)
+
 	// Create or update conversation
 	if err := a.db.CreateOrUpdateConversation(correlationID, userID, message.Content); err != nil {
 		a.logger.WithError(err).Warn("Failed to create/update conversation")
@@ -339,19 +560,56 @@ func (a *APRSClient) storeMessage(message *APRSMessage) error {
 	return nil
 }
 
-// sendACK sends an ACK back to the sender
-func (a *APRSClient) sendACK(toCallsign, messageID string) error {
-	if !a.connected {
-		return fmt.Errorf("not connected to APRS-IS")
+// handleIncomingAck resolves a pending outbound send against an "ackNNNNN"
+// reply, marking its originating database record delivered.
+func (a *APRSClient) handleIncomingAck(fromCallsign, messageID string) {
+	pending, ok := a.acks.Ack(fromCallsign, messageID)
+	if ok {
+		metrics.acksReceived.Inc()
+	} else {
+		a.logger.WithFields(logrus.Fields{
+			"from":       fromCallsign,
+			"message_id": messageID,
+		}).Debug("Received ACK for unknown or already-resolved message")
+		return
+	}
+
+	if pending.dbMessageID == "" {
+		return
+	}
+
+	if err := a.db.UpdateMessageStatus(pending.dbMessageID, "delivered", map[string]interface{}{
+		"aprs_message_id": messageID,
+		"delivery_method": "aprs-is",
+		"ack_attempts":    pending.attempts,
+	}); err != nil {
+		a.logger.WithError(err).Warn("Failed to mark ACKed message delivered")
 	}
+}
+
+// SendTrackedMessage sends a message via APRS and, if it carries a message
+// ID, begins tracking it for ACK retry so the sender loop can retransmit on
+// a schedule until it's acked or exhausted.
+func (a *APRSClient) SendTrackedMessage(toCallsign, content, messageID, dbMessageID string) error {
+	if err := a.SendMessage(toCallsign, content, messageID); err != nil {
+		return err
+	}
+	if messageID != "" {
+		a.acks.Track(toCallsign, messageID, content, dbMessageID)
+	}
+	return nil
+}
 
+// sendACK sends an ACK back to the sender
+func (a *APRSClient) sendACK(toCallsign, messageID string) error {
 	ackPacket := fmt.Sprintf("%s>APRS,TCPIP*::%s:ack%s\r\n",
-		a.config.APRS.Callsign, toCallsign, messageID)
+		a.Config().APRS.Callsign, toCallsign, messageID)
 
-	if _, err := a.conn.Write([]byte(ackPacket)); err != nil {
+	if err := a.writeLine(ackPacket); err != nil {
 		return fmt.Errorf("failed to send ACK: %w", err)
 	}
 
+	metrics.acksSent.Inc()
 	a.logger.WithFields(logrus.Fields{
 		"to":         toCallsign,
 		"message_id": messageID,
@@ -360,27 +618,31 @@ func (a *APRSClient) sendACK(toCallsign, messageID string) error {
 	return nil
 }
 
+// aprsMaxBodyLength is the maximum length of the "content" portion of an
+// APRS message addressee line, per the APRS spec.
+const aprsMaxBodyLength = 67
+
 // SendMessage sends a message via APRS
 func (a *APRSClient) SendMessage(toCallsign, content, messageID string) error {
-	if !a.connected {
-		return fmt.Errorf("not connected to APRS-IS")
-	}
-
-	// Truncate content if too long (APRS message limit is ~67 characters)
-	if len(content) > 67 {
-		content = content[:64] + "..."
+	// Truncate content to leave room for the "{messageID" ACK suffix, so the
+	// whole body (content + suffix) stays within aprsMaxBodyLength rather
+	// than just the content on its own.
+	maxContentLength := aprsMaxBodyLength
+	if messageID != "" {
+		maxContentLength -= len(messageID) + 1 // "{" + messageID
 	}
+	content = truncateForAPRS(content, maxContentLength)
 
 	var packet string
 	if messageID != "" {
 		packet = fmt.Sprintf("%s>APRS,TCPIP*::%s:%s{%s\r\n",
-			a.config.APRS.Callsign, toCallsign, content, messageID)
+			a.Config().APRS.Callsign, toCallsign, content, messageID)
 	} else {
 		packet = fmt.Sprintf("%s>APRS,TCPIP*::%s:%s\r\n",
-			a.config.APRS.Callsign, toCallsign, content)
+			a.Config().APRS.Callsign, toCallsign, content)
 	}
 
-	if _, err := a.conn.Write([]byte(packet)); err != nil {
+	if err := a.writeLine(packet); err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
 
@@ -393,37 +655,68 @@ func (a *APRSClient) SendMessage(toCallsign, content, messageID string) error {
 	return nil
 }
 
-// StartHeartbeat starts sending periodic heartbeat/beacon
+// StartHeartbeat starts sending periodic heartbeat/beacon for the current
+// connection. It reads the stop channel under the connection lock so it
+// coordinates with Disconnect instead of writing to a possibly-closed conn.
 func (a *APRSClient) StartHeartbeat() {
+	a.connMu.Lock()
+	stopChannel := a.stopChannel
+	a.connMu.Unlock()
+
 	go func() {
-		ticker := time.NewTicker(time.Duration(a.config.Services.APRSConnector.HeartbeatInterval) * time.Second)
+		ticker := time.NewTicker(time.Duration(a.Config().Services.APRSConnector.HeartbeatInterval) * time.Second)
 		defer ticker.Stop()
 
 		for {
 			select {
-			case <-a.stopChannel:
+			case <-stopChannel:
 				return
 			case <-ticker.C:
-				if a.connected {
-					// Send status beacon
-					statusPacket := fmt.Sprintf("%s>APRS,TCPIP*::STATUS :RARSMS online - bridging APRS to Discord\r\n",
-						a.config.APRS.Callsign)
-
-					if _, err := a.conn.Write([]byte(statusPacket)); err != nil {
-						a.logger.WithError(err).Warn("Failed to send heartbeat")
-					} else {
-						a.logger.Debug("Sent heartbeat beacon")
-					}
-
-					// Update system status with current stats
-					if err := a.db.UpdateSystemStatus("aprs-connector", "online", map[string]interface{}{
-						"last_heartbeat": time.Now().Unix(),
-						"connected":      true,
-					}); err != nil {
-						a.logger.WithError(err).Warn("Failed to update heartbeat status")
-					}
+				if !a.IsConnected() {
+					continue
+				}
+
+				// Send status beacon
+				statusPacket := fmt.Sprintf("%s>APRS,TCPIP*::STATUS :RARSMS online - bridging APRS to Discord\r\n",
+					a.Config().APRS.Callsign)
+
+				if err := a.writeLine(statusPacket); err != nil {
+					a.logger.WithError(err).Warn("Failed to send heartbeat")
+				} else {
+					a.logger.Debug("Sent heartbeat beacon")
+				}
+
+				// Update system status with current stats
+				if err := a.db.UpdateSystemStatus("aprs-connector", "online", map[string]interface{}{
+					"last_heartbeat": time.Now().Unix(),
+					"connected":      true,
+				}); err != nil {
+					a.logger.WithError(err).Warn("Failed to update heartbeat status")
 				}
 			}
 		}
 	}()
+}
+
+// runWatchdog forces the connection closed if no traffic has arrived within
+// timeout, which in turn makes the blocking scanner.Scan() in Listen return
+// an error so the supervising loop in runService can reconnect.
+func (a *APRSClient) runWatchdog(conn net.Conn, stopChannel chan bool, done chan struct{}, timeout time.Duration) {
+	ticker := time.NewTicker(timeout / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-stopChannel:
+			return
+		case <-ticker.C:
+			if time.Since(a.LastActivity()) > timeout {
+				a.logger.WithField("timeout", timeout).Warn("No APRS-IS traffic within watchdog window, forcing reconnect")
+				conn.Close()
+				return
+			}
+		}
+	}
 }
\ No newline at end of file
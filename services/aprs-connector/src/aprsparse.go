@@ -0,0 +1,501 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PositionReport is a decoded APRS position (packet types '!', '=', '/', '@',
+// or a MIC-E encoded position carried in the destination field).
+type PositionReport struct {
+	FromCallsign string
+	Latitude     float64
+	Longitude    float64
+	SymbolTable  byte
+	SymbolCode   byte
+	Comment      string
+	Compressed   bool
+	MicE         bool
+	Timestamp    time.Time
+}
+
+// WeatherReport is a decoded APRS weather packet ('_' or wx fields embedded
+// in a position packet's comment).
+type WeatherReport struct {
+	FromCallsign  string
+	WindDirection int // degrees
+	WindSpeedMPH  int
+	GustMPH       int
+	TempF         int
+	RainLastHour  float64 // inches
+	RainLast24h   float64 // inches
+	RainSinceMid  float64 // inches
+	Humidity      int     // percent
+	PressureTenth int     // tenths of millibars
+	Timestamp     time.Time
+}
+
+// Telemetry is a decoded "T#" telemetry packet.
+type Telemetry struct {
+	FromCallsign string
+	Sequence     int
+	Analog       [5]float64
+	Digital      string
+	Timestamp    time.Time
+}
+
+// Object is a decoded object ';' or item ')' report.
+type Object struct {
+	FromCallsign string
+	Name         string
+	Live         bool
+	Latitude     float64
+	Longitude    float64
+	SymbolTable  byte
+	SymbolCode   byte
+	Comment      string
+	Timestamp    time.Time
+}
+
+// Status is a decoded '>' status packet.
+type Status struct {
+	FromCallsign string
+	Text         string
+	Timestamp    time.Time
+}
+
+var (
+	packetHeaderRegex = regexp.MustCompile(`^([A-Z0-9-]+)>([^,:]+(?:,[^,:]+)*):(.*)$`)
+
+	uncompressedPosRegex = regexp.MustCompile(
+		`^(\d{2})(\d{2}\.\d+)([NS])(.)(\d{3})(\d{2}\.\d+)([EW])(.)(.*)$`)
+	weatherFieldsRegex = regexp.MustCompile(
+		`(\d{3})/(\d{3})g(\d{3})t(-?\d{3})(?:r(\d{3}))?(?:p(\d{3}))?(?:P(\d{3}))?(?:h(\d{2}))?(?:b(\d{5}))?`)
+	telemetryRegex = regexp.MustCompile(
+		`^T#(\d+),([\d.]+),([\d.]+),([\d.]+),([\d.]+),([\d.]+),([01]+)`)
+)
+
+// splitPacketHeader breaks a raw TNC-2 line into source, destination+path,
+// and info field, e.g. "W4ABC>APRS,TCPIP*:!3500.00N/08000.00Wk...".
+func splitPacketHeader(rawPacket string) (from, toPath, info string, ok bool) {
+	matches := packetHeaderRegex.FindStringSubmatch(rawPacket)
+	if len(matches) != 4 {
+		return "", "", "", false
+	}
+	return strings.ToUpper(matches[1]), matches[2], matches[3], true
+}
+
+// classifyAndParse identifies the packet type of a non-message APRS packet
+// and decodes it into one of PositionReport, WeatherReport, Telemetry,
+// Object, or Status. It returns a non-nil error for packet types that aren't
+// recognized as well as for malformed ones; plenty of APRS-IS traffic
+// (third-party packets, unknown extensions) falls outside the common types.
+func classifyAndParse(rawPacket string) (interface{}, string, error) {
+	from, destPath, info, ok := splitPacketHeader(rawPacket)
+	if !ok || info == "" {
+		return nil, "", fmt.Errorf("packet has no parseable info field")
+	}
+
+	switch info[0] {
+	case '!', '=', '/', '@':
+		pos, err := parsePositionPacket(from, info)
+		if err != nil {
+			return nil, "", err
+		}
+		if wx, wxErr := parseWeatherFromComment(from, pos.Comment, pos.Timestamp); wxErr == nil {
+			return wx, "weather", nil
+		}
+		return pos, "position", nil
+	case '_':
+		wx, err := parseWeatherPacket(from, info)
+		if err != nil {
+			return nil, "", err
+		}
+		return wx, "weather", nil
+	case ';':
+		obj, err := parseObjectPacket(from, info)
+		if err != nil {
+			return nil, "", err
+		}
+		return obj, "object", nil
+	case ')':
+		item, err := parseItemPacket(from, info)
+		if err != nil {
+			return nil, "", err
+		}
+		return item, "object", nil
+	case 'T':
+		tel, err := parseTelemetryPacket(from, info)
+		if err != nil {
+			return nil, "", err
+		}
+		return tel, "telemetry", nil
+	case '>':
+		return &Status{FromCallsign: from, Text: strings.TrimSpace(info[1:]), Timestamp: time.Now()}, "status", nil
+	default:
+		// MIC-E packets encode position in the destination field and use a
+		// handful of reserved leading bytes in the info field.
+		if mice, err := parseMicEPacket(from, destPath, info); err == nil {
+			return mice, "position", nil
+		}
+		return nil, "", fmt.Errorf("unrecognized packet type %q", info[0])
+	}
+}
+
+// parsePositionPacket decodes both uncompressed (DDMM.mmN/DDDMM.mmW) and
+// Base91-compressed position formats.
+func parsePositionPacket(from, info string) (*PositionReport, error) {
+	body := info[1:]
+
+	// Timestamped position reports ('/' and '@') carry a fixed 7-byte
+	// DHM or HMS timestamp before the position; skip it for our purposes.
+	if (info[0] == '/' || info[0] == '@') && len(body) >= 7 {
+		body = body[7:]
+	}
+
+	if len(body) == 0 {
+		return nil, fmt.Errorf("empty position body")
+	}
+
+	// Try the uncompressed DDMM.mmN/DDDMM.mmW format first: it's unambiguous
+	// (fixed digit positions, N/S/E/W hemisphere letters) whereas the
+	// compressed format's leading symbol-table byte overlaps with ordinary
+	// digits, so checking compressed first would misfire on real
+	// uncompressed packets.
+	if matches := uncompressedPosRegex.FindStringSubmatch(body); matches != nil {
+		lat, err := dmToDecimal(matches[1], matches[2], matches[3])
+		if err != nil {
+			return nil, err
+		}
+		lon, err := dmToDecimal(matches[5], matches[6], matches[7])
+		if err != nil {
+			return nil, err
+		}
+
+		return &PositionReport{
+			FromCallsign: from,
+			Latitude:     lat,
+			Longitude:    lon,
+			SymbolTable:  matches[4][0],
+			SymbolCode:   matches[8][0],
+			Comment:      strings.TrimSpace(matches[9]),
+			Timestamp:    time.Now(),
+		}, nil
+	}
+
+	// Compressed format: symbol table char, 4 chars lat, 4 chars lon, symbol code.
+	if isBase91Char(body[0]) && len(body) >= 13 {
+		lat, lon, err := decodeCompressedPosition(body[1:9])
+		if err == nil {
+			return &PositionReport{
+				FromCallsign: from,
+				Latitude:     lat,
+				Longitude:    lon,
+				SymbolTable:  body[0],
+				SymbolCode:   body[9],
+				Comment:      strings.TrimSpace(body[10:]),
+				Compressed:   true,
+				Timestamp:    time.Now(),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("position body does not match uncompressed or compressed format")
+}
+
+// dmToDecimal converts APRS degrees-minutes notation (e.g. "35", "00.50",
+// "N") into signed decimal degrees.
+func dmToDecimal(degrees, minutes, hemisphere string) (float64, error) {
+	deg, err := strconv.ParseFloat(degrees, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid degrees %q: %w", degrees, err)
+	}
+	min, err := strconv.ParseFloat(minutes, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid minutes %q: %w", minutes, err)
+	}
+
+	decimal := deg + min/60
+	if hemisphere == "S" || hemisphere == "W" {
+		decimal = -decimal
+	}
+	return decimal, nil
+}
+
+// isBase91Char reports whether b is a valid Base91 digit ('!' through '{').
+func isBase91Char(b byte) bool {
+	return b >= '!' && b <= '{'
+}
+
+// decodeCompressedPosition decodes the 8-character Base91 compressed
+// lat/lon pair used by compressed position packets, per the APRS101 spec.
+func decodeCompressedPosition(field string) (lat, lon float64, err error) {
+	if len(field) != 8 {
+		return 0, 0, fmt.Errorf("compressed position field must be 8 characters")
+	}
+
+	latVal, err := base91Decode(field[0:4])
+	if err != nil {
+		return 0, 0, err
+	}
+	lonVal, err := base91Decode(field[4:8])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	lat = 90 - float64(latVal)/380926
+	lon = -180 + float64(lonVal)/190463
+	return lat, lon, nil
+}
+
+// base91Decode decodes a fixed-width Base91 string into its integer value,
+// as used by APRS compressed position and altitude encodings. Per the
+// APRS101 spec each character's value is simply its ASCII code minus 33
+// ('!'), valid over the range 0-90 ('!' through '{') — there is no
+// alphabet-substitution table.
+func base91Decode(s string) (int64, error) {
+	var value int64
+	for _, c := range s {
+		idx := int(c) - 33
+		if idx < 0 || idx > 90 {
+			return 0, fmt.Errorf("invalid base91 character %q", c)
+		}
+		value = value*91 + int64(idx)
+	}
+	return value, nil
+}
+
+// parseWeatherPacket decodes a standalone '_' weather packet.
+func parseWeatherPacket(from, info string) (*WeatherReport, error) {
+	// Format: _MMDDHHMM + weather fields, e.g. _10090556c220s004g005t077...
+	body := info
+	if len(body) > 9 {
+		body = body[9:]
+	}
+	// Positionless reports write wind as cDDDsSSS; rewrite it to the DDD/SSS
+	// form parseWeatherFields shares with position-embedded weather.
+	if len(body) >= 8 && body[0] == 'c' && body[4] == 's' {
+		body = body[1:4] + "/" + body[5:]
+	}
+	return parseWeatherFields(from, body)
+}
+
+// parseWeatherFromComment looks for wx fields embedded in a position
+// packet's comment (the common case for most APRS weather stations, which
+// send a position packet with weather data appended).
+func parseWeatherFromComment(from, comment string, ts time.Time) (*WeatherReport, error) {
+	return parseWeatherFields(from, comment)
+}
+
+func parseWeatherFields(from, fields string) (*WeatherReport, error) {
+	matches := weatherFieldsRegex.FindStringSubmatch(fields)
+	if matches == nil {
+		return nil, fmt.Errorf("no recognizable weather fields")
+	}
+
+	atoi := func(s string) int {
+		v, _ := strconv.Atoi(s)
+		return v
+	}
+
+	wx := &WeatherReport{
+		FromCallsign:  from,
+		WindDirection: atoi(matches[1]),
+		WindSpeedMPH:  atoi(matches[2]),
+		GustMPH:       atoi(matches[3]),
+		TempF:         atoi(matches[4]),
+		Timestamp:     time.Now(),
+	}
+	if matches[5] != "" {
+		wx.RainLastHour = float64(atoi(matches[5])) / 100
+	}
+	if matches[6] != "" {
+		wx.RainLast24h = float64(atoi(matches[6])) / 100
+	}
+	if matches[7] != "" {
+		wx.RainSinceMid = float64(atoi(matches[7])) / 100
+	}
+	if matches[8] != "" {
+		wx.Humidity = atoi(matches[8])
+	}
+	if matches[9] != "" {
+		wx.PressureTenth = atoi(matches[9])
+	}
+
+	return wx, nil
+}
+
+// parseTelemetryPacket decodes a "T#seq,a1,a2,a3,a4,a5,digital" packet.
+func parseTelemetryPacket(from, info string) (*Telemetry, error) {
+	matches := telemetryRegex.FindStringSubmatch(info)
+	if matches == nil {
+		return nil, fmt.Errorf("telemetry packet does not match T# format")
+	}
+
+	seq, _ := strconv.Atoi(matches[1])
+	tel := &Telemetry{
+		FromCallsign: from,
+		Sequence:     seq,
+		Digital:      matches[7],
+		Timestamp:    time.Now(),
+	}
+	for i := 0; i < 5; i++ {
+		tel.Analog[i], _ = strconv.ParseFloat(matches[i+2], 64)
+	}
+
+	return tel, nil
+}
+
+// parseObjectPacket decodes a ';' object report.
+func parseObjectPacket(from, info string) (*Object, error) {
+	// Format: ;NAME-----*DDHHMMz + position, where '*' means live, '_' means killed.
+	if len(info) < 30 {
+		return nil, fmt.Errorf("object packet too short")
+	}
+
+	name := strings.TrimSpace(info[1:10])
+	live := info[10] == '*'
+	posAndComment := info[18:]
+
+	pos, err := parsePositionPacket(from, "!"+posAndComment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Object{
+		FromCallsign: from,
+		Name:         name,
+		Live:         live,
+		Latitude:     pos.Latitude,
+		Longitude:    pos.Longitude,
+		SymbolTable:  pos.SymbolTable,
+		SymbolCode:   pos.SymbolCode,
+		Comment:      pos.Comment,
+		Timestamp:    time.Now(),
+	}, nil
+}
+
+// parseItemPacket decodes a ')' item report, which is laid out like an
+// object but with a variable-length name terminated by '!' or '_'.
+func parseItemPacket(from, info string) (*Object, error) {
+	end := strings.IndexAny(info[1:], "!_")
+	if end < 0 {
+		return nil, fmt.Errorf("item packet missing live/killed marker")
+	}
+	end++ // account for the leading ')'
+
+	name := info[1:end]
+	live := info[end] == '!'
+	pos, err := parsePositionPacket(from, "!"+info[end+1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Object{
+		FromCallsign: from,
+		Name:         name,
+		Live:         live,
+		Latitude:     pos.Latitude,
+		Longitude:    pos.Longitude,
+		SymbolTable:  pos.SymbolTable,
+		SymbolCode:   pos.SymbolCode,
+		Comment:      pos.Comment,
+		Timestamp:    time.Now(),
+	}, nil
+}
+
+// parseMicEPacket decodes a MIC-E encoded position, where latitude, the N/S
+// and E/W hemispheres, and the longitude offset are packed into the
+// destination callsign field per the APRS101 spec.
+func parseMicEPacket(from, destPath, info string) (*PositionReport, error) {
+	dest := strings.SplitN(destPath, ",", 2)[0]
+	if len(dest) < 6 || len(info) < 9 {
+		return nil, fmt.Errorf("packet too short for MIC-E")
+	}
+	// MIC-E data type bytes are restricted to a handful of values;
+	// reject anything that clearly isn't one of them.
+	if !strings.ContainsRune("`'\x1c\x1d", rune(info[0])) {
+		return nil, fmt.Errorf("not a MIC-E data type byte")
+	}
+
+	digits := make([]byte, 6)
+	north := false
+	longOffset := false
+	westSign := 1.0
+
+	for i := 0; i < 6; i++ {
+		c := dest[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits[i] = c
+		case c >= 'A' && c <= 'J':
+			digits[i] = c - 'A' + '0'
+			if i == 3 {
+				north = true
+			}
+		case c >= 'P' && c <= 'Y':
+			digits[i] = c - 'P' + '0'
+			if i == 3 {
+				north = true
+			}
+			if i == 4 {
+				longOffset = true
+			}
+			if i == 5 {
+				westSign = -1
+			}
+		case c == 'K' || c == 'L' || c == 'Z':
+			digits[i] = '0'
+		default:
+			return nil, fmt.Errorf("invalid MIC-E destination character %q", c)
+		}
+	}
+
+	latDeg := float64((digits[0]-'0'))*10 + float64(digits[1]-'0')
+	latMin := float64((digits[2]-'0'))*10 + float64(digits[3]-'0')
+	latMinHundredths := float64(digits[4]-'0')*10 + float64(digits[5]-'0')
+	lat := latDeg + (latMin+latMinHundredths/100)/60
+	if !north {
+		lat = -lat
+	}
+
+	if len(info) < 9 {
+		return nil, fmt.Errorf("MIC-E info field too short for longitude")
+	}
+	lonDeg := int(info[1]) - 28
+	if longOffset {
+		lonDeg += 100
+	}
+	if lonDeg >= 180 && lonDeg <= 189 {
+		lonDeg -= 80
+	} else if lonDeg >= 190 && lonDeg <= 199 {
+		lonDeg -= 190
+	}
+	lonMin := int(info[2]) - 28
+	if lonMin >= 60 {
+		lonMin -= 60
+	}
+	lonMinHundredths := int(info[3]) - 28
+
+	lon := westSign * (float64(lonDeg) + (float64(lonMin)+float64(lonMinHundredths)/100)/60)
+
+	symbolCode := byte('>')
+	symbolTable := byte('/')
+	if len(info) >= 9 {
+		symbolCode = info[7]
+		symbolTable = info[8]
+	}
+
+	return &PositionReport{
+		FromCallsign: from,
+		Latitude:     lat,
+		Longitude:    lon,
+		SymbolTable:  symbolTable,
+		SymbolCode:   symbolCode,
+		MicE:         true,
+		Timestamp:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import "context"
+
+// Bridge is a peer the connector relays messages to and from. IRCBridge is
+// the only implementation; APRS-IS traffic is handled by APRSClient through
+// runService/runMessageSender, and "discord" exists only as a routing
+// destination name, not as a Bridge. Adding a future SMS or Matrix peer means
+// implementing this interface rather than threading a new special case
+// through the sender loop.
+type Bridge interface {
+	// Name identifies the bridge as a routing destination, e.g. "aprs",
+	// "discord", "irc". It is the value stored in a message's routes array.
+	Name() string
+
+	// Send delivers a message to this bridge's peer.
+	Send(ctx context.Context, msg BridgeMessage) error
+
+	// Receive returns the channel messages originating from this bridge are
+	// published on. The channel is closed when the bridge stops.
+	Receive() <-chan BridgeMessage
+
+	// Start connects the bridge and begins publishing to Receive().
+	Start(ctx context.Context) error
+
+	// Stop disconnects the bridge and closes the Receive channel.
+	Stop() error
+}
+
+// BridgeMessage is the bridge-agnostic shape routed between peers. It is
+// deliberately smaller than the messages collection schema: bridges
+// translate it to and from their own wire format, and the sender loop
+// translates it to and from PocketBase records.
+type BridgeMessage struct {
+	CorrelationID string
+	FromCallsign  string
+	FromService   string
+	Content       string
+	MessageID     string
+	// TargetCallsign is the APRS station this message is addressed to, if
+	// the bridge's wire format carries one (e.g. IRC's "CALLSIGN: text"
+	// convention). Empty means the bridge has no addressee of its own,
+	// and a consumer routing this onward to APRS should fall back to
+	// FromCallsign.
+	TargetCallsign string
+}
@@ -5,26 +5,55 @@ import (
 	"gopkg.in/yaml.v3"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
 )
 
 // Config represents the application configuration
 type Config struct {
+	// Version is the on-disk schema version. Missing or 0 means the
+	// original unversioned schema; LoadConfig migrates it up to
+	// CurrentSchemaVersion before decoding into this struct, via
+	// migrateConfig in configmigrate.go.
+	Version  int            `yaml:"version"`
 	APRS     APRSConfig     `yaml:"aprs"`
 	Database DatabaseConfig `yaml:"database"`
 	Logging  LoggingConfig  `yaml:"logging"`
 	Services ServicesConfig `yaml:"services"`
+	// Paths authorizes and constrains traffic per sending callsign pattern.
+	// Reloadable at runtime; see ConfigHolder.
+	Paths map[string]PathConfig `yaml:"paths"`
 }
 
 // APRSConfig contains APRS-IS connection settings
 type APRSConfig struct {
-	Callsign        string `yaml:"callsign"`
-	Passcode        string `yaml:"passcode"`
-	Server          string `yaml:"server"`
-	Port            int    `yaml:"port"`
-	Filter          string `yaml:"filter"`
-	BeaconInterval  int    `yaml:"beacon_interval"`
+	Callsign        string             `yaml:"callsign"`
+	Passcode        string             `yaml:"passcode"`
+	Server          string             `yaml:"server"`
+	Port            int                `yaml:"port"`
+	Filter          string             `yaml:"filter"`
+	BeaconInterval  int                `yaml:"beacon_interval"`
+	Endpoints       []APRSEndpoint     `yaml:"endpoints"`
+	EndpointPool    EndpointPoolConfig `yaml:"endpoint_pool"`
+	ReadOnly        bool               `yaml:"read_only"`
+	TLS             bool               `yaml:"tls"`
+	WatchdogSeconds int                `yaml:"watchdog_seconds"`
+
+	// PasscodeDerived records whether resolveAPRSPasscode computed
+	// Passcode itself rather than the operator supplying one, so main can
+	// log the derived value once the logger is available.
+	PasscodeDerived bool `yaml:"-"`
+}
+
+// EndpointPoolConfig controls failover behavior across APRS.Endpoints.
+type EndpointPoolConfig struct {
+	CooldownSeconds int `yaml:"cooldown_seconds"`
+	MaxBackoff      int `yaml:"max_backoff_seconds"`
 }
 
 // DatabaseConfig contains PocketBase connection settings
@@ -39,30 +68,243 @@ type LoggingConfig struct {
 	Level  string `yaml:"level"`
 	Format string `yaml:"format"`
 	Output string `yaml:"output"`
+
+	// Sink selects the primary log destination: stdout, stderr, file,
+	// syslog, or http. Defaults to Output for backward compatibility.
+	Sink       string           `yaml:"sink"`
+	File       FileSinkConfig   `yaml:"file"`
+	Syslog     SyslogSinkConfig `yaml:"syslog"`
+	HTTP       HTTPSinkConfig   `yaml:"http"`
+	// FanOutLevels additionally routes entries at or above these levels to
+	// the HTTP sink even when it is not the primary sink, e.g. ["warn", "error"].
+	FanOutLevels []string `yaml:"fan_out_levels"`
+
+	// PackageLevels overrides Level for individual subsystems, parsed as
+	// "aprs=debug,db=info,irc=warn" (see GetLogger in logging.go). Unknown
+	// subsystem names are rejected at load time.
+	PackageLevels string `yaml:"package_levels"`
+}
+
+// FileSinkConfig configures rotation for the file log sink.
+type FileSinkConfig struct {
+	Path       string `yaml:"path"`
+	MaxSize    int    `yaml:"max_size"`    // megabytes
+	MaxAge     int    `yaml:"max_age"`     // days
+	MaxBackups int    `yaml:"max_backups"`
+	Compress   bool   `yaml:"compress"`
+}
+
+// SyslogSinkConfig configures the syslog log sink.
+type SyslogSinkConfig struct {
+	Network string `yaml:"network"` // "", "tcp", or "udp"; "" means local syslog
+	Address string `yaml:"address"`
+	Tag     string `yaml:"tag"`
+}
+
+// HTTPSinkConfig configures the HTTP webhook log sink.
+type HTTPSinkConfig struct {
+	URL        string `yaml:"url"`
+	BufferSize int    `yaml:"buffer_size"`
+	MaxRetries int    `yaml:"max_retries"`
+	TimeoutSec int    `yaml:"timeout_seconds"`
+}
+
+// PathConfig authorizes and constrains traffic from callsigns matching a
+// path pattern ("KD4*", "N0CALL-9", or the catch-all "all").
+type PathConfig struct {
+	AllowedMessageTypes []string `yaml:"allowed_message_types"`
+	AllowedDestinations []string `yaml:"allowed_destinations"`
+	RateLimitPerSec     float64  `yaml:"rate_limit_per_sec"`
+	// SkipMemberAuth bypasses the PocketBase member-authorization check for
+	// callsigns matching this path. Defaults to false (still check).
+	SkipMemberAuth bool `yaml:"skip_member_auth"`
+	// Filter is an APRS-IS filter fragment contributed by this path,
+	// appended to the global filter when building the login string.
+	Filter string `yaml:"filter"`
+}
+
+// allows reports whether messageType and destination are permitted by this
+// path. Empty allow-lists mean "no restriction" for that dimension.
+func (p PathConfig) allows(messageType, destination string) bool {
+	if len(p.AllowedMessageTypes) > 0 && !containsFold(p.AllowedMessageTypes, messageType) {
+		return false
+	}
+	if len(p.AllowedDestinations) > 0 && !containsFold(p.AllowedDestinations, destination) {
+		return false
+	}
+	return true
+}
+
+func containsFold(list []string, val string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, val) {
+			return true
+		}
+	}
+	return false
+}
+
+// PathFor returns the most specific path rule matching callsign: an exact
+// match, then the longest matching glob ("KD4*" matches KD4ABC), then the
+// "all" catch-all. The bool is false if no rule (including "all") matches.
+func (c *Config) PathFor(callsign string) (PathConfig, bool) {
+	callsign = strings.ToUpper(callsign)
+
+	if p, ok := c.Paths[callsign]; ok {
+		return p, true
+	}
+
+	var best string
+	for pattern := range c.Paths {
+		if pattern == "all" || !strings.HasSuffix(pattern, "*") {
+			continue
+		}
+		prefix := strings.TrimSuffix(pattern, "*")
+		if strings.HasPrefix(callsign, prefix) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	if best != "" {
+		return c.Paths[best+"*"], true
+	}
+
+	if p, ok := c.Paths["all"]; ok {
+		return p, true
+	}
+	return PathConfig{}, false
+}
+
+// BuildFilter combines the global APRS filter with the filter fragment
+// contributed by each configured path, for use in the APRS-IS login string.
+func (c *Config) BuildFilter() string {
+	parts := []string{}
+	if c.APRS.Filter != "" {
+		parts = append(parts, c.APRS.Filter)
+	}
+	for _, p := range c.Paths {
+		if p.Filter != "" {
+			parts = append(parts, p.Filter)
+		}
+	}
+	return strings.Join(parts, " ")
 }
 
 // ServicesConfig contains service-specific settings
 type ServicesConfig struct {
 	APRSConnector APRSConnectorConfig `yaml:"aprs_connector"`
+	IRC           IRCConfig           `yaml:"irc"`
+	Routing       RoutingConfig       `yaml:"routing"`
+	Metrics       MetricsConfig       `yaml:"metrics"`
+}
+
+// MetricsConfig controls the operator-facing HTTP server exposing
+// Prometheus metrics and a live WebSocket event stream.
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Port    int    `yaml:"port"`
+	// EventsToken is the bearer token WebSocket clients must present to
+	// subscribe to /events. Empty disables the check (local/dev only).
+	EventsToken string `yaml:"events_token"`
+}
+
+// IRCConfig contains settings for the IRC bridge.
+type IRCConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Server  string `yaml:"server"`
+	Port    int    `yaml:"port"`
+	TLS     bool   `yaml:"tls"`
+	Nick    string `yaml:"nick"`
+	Channel string `yaml:"channel"`
+	// NickSuffix is appended to a relayed callsign to form its IRC nick,
+	// e.g. "[APRS]" turns W1AW into W1AW[APRS].
+	NickSuffix string `yaml:"nick_suffix"`
+}
+
+// RoutingConfig controls which destinations each source service's messages
+// fan out to.
+type RoutingConfig struct {
+	Rules []RouteRule `yaml:"rules"`
+}
+
+// RouteRule maps a source bridge to the destination bridges its messages
+// should be routed to.
+type RouteRule struct {
+	Source       string   `yaml:"source"`
+	Destinations []string `yaml:"destinations"`
+}
+
+// DestinationsFor returns the configured destinations for a source, or
+// ["discord"] (the historical Phase 1 default) if no rule matches.
+func (c RoutingConfig) DestinationsFor(source string) []string {
+	for _, rule := range c.Rules {
+		if rule.Source == source {
+			return rule.Destinations
+		}
+	}
+	return []string{"discord"}
 }
 
 // APRSConnectorConfig contains APRS connector specific settings
 type APRSConnectorConfig struct {
-	Enabled           bool `yaml:"enabled"`
-	ReconnectDelay    int  `yaml:"reconnect_delay"`
-	HeartbeatInterval int  `yaml:"heartbeat_interval"`
+	Enabled           bool              `yaml:"enabled"`
+	ReconnectDelay    int               `yaml:"reconnect_delay"`
+	HeartbeatInterval int               `yaml:"heartbeat_interval"`
+	SweepInterval     int               `yaml:"sweep_interval"`
+	Workers           int               `yaml:"workers"`
+	GlobalRate        float64           `yaml:"global_rate"`
+	PerCallsignRate   float64           `yaml:"per_callsign_rate"`
+	Retry             RetryPolicyConfig `yaml:"retry"`
+}
+
+// RetryPolicyConfig is the on-disk form of RetryPolicy; durations are
+// expressed in seconds so the YAML stays plain integers.
+type RetryPolicyConfig struct {
+	MaxRetries          int     `yaml:"max_retries"`
+	InitialDelaySeconds int     `yaml:"initial_delay_seconds"`
+	MaxDelaySeconds     int     `yaml:"max_delay_seconds"`
+	TotalTimeoutSeconds int     `yaml:"total_timeout_seconds"`
+	Jitter              float64 `yaml:"jitter"`
+	BackoffStrategy     string  `yaml:"backoff_strategy"` // exponential, fibonacci, constant
+}
+
+// ToPolicy converts the on-disk config into the RetryPolicy consumed by
+// newBackoffSequence and checkRetryBounds.
+func (c RetryPolicyConfig) ToPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:      c.MaxRetries,
+		InitialDelay:    time.Duration(c.InitialDelaySeconds) * time.Second,
+		MaxDelay:        time.Duration(c.MaxDelaySeconds) * time.Second,
+		TotalTimeout:    time.Duration(c.TotalTimeoutSeconds) * time.Second,
+		Jitter:          c.Jitter,
+		BackoffStrategy: BackoffStrategy(c.BackoffStrategy),
+	}
 }
 
-// LoadConfig loads configuration from file and environment variables
-func LoadConfig(configPath string) (*Config, error) {
+// LoadConfig builds the effective configuration by layering sources in
+// increasing precedence: built-in defaults, the primary YAML file,
+// conf.d-style fragments from configDir, the optional configOverlay
+// .ini/.toml file, environment variables, and finally any CLI flags
+// explicitly set on flags. Each layer only overrides the keys it sets, so
+// a later layer can override a single field without resetting the rest of
+// the struct. flags may be nil, in which case the CLI layer is skipped
+// (as ConfigHolder.Reload does) and the overlay/environment layers are still applied
+// through an unparsed flag set built just for this call.
+//
+// Before the primary file is decoded into Config, it passes through
+// migrateConfig, which upgrades an older on-disk schema to
+// CurrentSchemaVersion. If writeBack is true and a migration actually
+// ran, the upgraded YAML is written back over configPath, alongside a
+// ".bak" copy of the original.
+func LoadConfig(configPath, configDir, configOverlay string, flags *pflag.FlagSet, writeBack bool) (*Config, error) {
 	config := &Config{
 		// Set defaults
 		APRS: APRSConfig{
-			Callsign:       "RARSMS",
-			Server:         "rotate.aprs2.net",
-			Port:           14580,
-			Filter:         "t/m",
-			BeaconInterval: 1800,
+			Callsign:        "RARSMS",
+			Server:          "rotate.aprs2.net",
+			Port:            14580,
+			Filter:          "t/m",
+			BeaconInterval:  1800,
+			WatchdogSeconds: 90,
 		},
 		Database: DatabaseConfig{
 			URL: "http://pocketbase:8090",
@@ -71,15 +313,49 @@ func LoadConfig(configPath string) (*Config, error) {
 			Level:  "info",
 			Format: "json",
 			Output: "stdout",
+			File: FileSinkConfig{
+				MaxSize:    100,
+				MaxAge:     28,
+				MaxBackups: 7,
+			},
+			HTTP: HTTPSinkConfig{
+				BufferSize: 256,
+				MaxRetries: 3,
+				TimeoutSec: 5,
+			},
 		},
 		Services: ServicesConfig{
 			APRSConnector: APRSConnectorConfig{
 				Enabled:           true,
 				ReconnectDelay:    30,
 				HeartbeatInterval: 300,
+				SweepInterval:     10,
+				Workers:           4,
+				GlobalRate:        6,
+				PerCallsignRate:   1,
+				Retry: RetryPolicyConfig{
+					MaxRetries:          5,
+					InitialDelaySeconds: 1,
+					MaxDelaySeconds:     60,
+					TotalTimeoutSeconds: 0,
+					Jitter:              0.2,
+					BackoffStrategy:     "fibonacci",
+				},
+			},
+			IRC: IRCConfig{
+				Port:       6667,
+				NickSuffix: "[APRS]",
+			},
+			Metrics: MetricsConfig{
+				Enabled: true,
+				Port:    9090,
 			},
 		},
 	}
+	config.APRS.EndpointPool = EndpointPoolConfig{
+		CooldownSeconds: 120,
+		MaxBackoff:      60,
+	}
 
 	// Load from config file if it exists
 	if configPath != "" {
@@ -89,14 +365,71 @@ func LoadConfig(configPath string) (*Config, error) {
 				return nil, fmt.Errorf("failed to read config file: %w", err)
 			}
 
-			if err := yaml.Unmarshal(data, config); err != nil {
+			migrated, changed, err := migrateConfigBytes(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to migrate config file: %w", err)
+			}
+
+			if changed && writeBack {
+				if err := ioutil.WriteFile(configPath+".bak", data, 0644); err != nil {
+					return nil, fmt.Errorf("failed to back up config file before migration: %w", err)
+				}
+				if err := ioutil.WriteFile(configPath, migrated, 0644); err != nil {
+					return nil, fmt.Errorf("failed to write migrated config file: %w", err)
+				}
+			}
+
+			if err := yaml.Unmarshal(migrated, config); err != nil {
 				return nil, fmt.Errorf("failed to parse config file: %w", err)
 			}
 		}
 	}
 
-	// Override with environment variables
-	loadEnvOverrides(config)
+	// Merge conf.d-style overlay fragments, in filename order, so
+	// deployments can drop in per-path or per-service overrides without
+	// editing the main file.
+	if err := mergeConfigDir(config, configDir); err != nil {
+		return nil, err
+	}
+
+	// The remaining layers (overlay file, environment, CLI) are all applied
+	// through a flag set, so they share one mechanism (fs.Changed) instead
+	// of three. flags is nil when called from ConfigHolder.Reload, which
+	// intentionally skips the CLI layer; build an unparsed flag set just
+	// for the overlay/environment layers in that case.
+	fs := flags
+	if fs == nil {
+		fs = BuildFlagSet("")
+	}
+
+	if err := loadConfigOverlay(configOverlay, fs); err != nil {
+		return nil, err
+	}
+
+	// Override with environment variables; skips any key already set by
+	// the overlay file or (when flags came from the caller) the CLI.
+	loadEnvOverrides(fs)
+
+	// Override with CLI flags (and anything loadConfigOverlay/
+	// loadEnvOverrides just set) explicitly marked Changed; unset flags
+	// leave the value from the layers above untouched.
+	applyFlagOverrides(config, fs)
+
+	resolveAPRSPasscode(config)
+
+	// Fall back to a single-endpoint pool built from the legacy
+	// aprs.server/port/passcode fields when no explicit endpoint list is given.
+	if len(config.APRS.Endpoints) == 0 {
+		config.APRS.Endpoints = []APRSEndpoint{
+			{
+				Host:     config.APRS.Server,
+				Port:     config.APRS.Port,
+				Passcode: config.APRS.Passcode,
+				Priority: 0,
+				Timeout:  30 * time.Second,
+			},
+		}
+	}
 
 	// Validate configuration
 	if err := validateConfig(config); err != nil {
@@ -106,55 +439,38 @@ func LoadConfig(configPath string) (*Config, error) {
 	return config, nil
 }
 
-// loadEnvOverrides loads configuration overrides from environment variables
-func loadEnvOverrides(config *Config) {
-	// APRS configuration
-	if val := os.Getenv("APRS_CALLSIGN"); val != "" {
-		config.APRS.Callsign = strings.ToUpper(val)
-	}
-	if val := os.Getenv("APRS_PASSCODE"); val != "" {
-		config.APRS.Passcode = val
-	}
-	if val := os.Getenv("APRS_SERVER"); val != "" {
-		config.APRS.Server = val
-	}
-	if val := os.Getenv("APRS_PORT"); val != "" {
-		if port, err := strconv.Atoi(val); err == nil {
-			config.APRS.Port = port
-		}
-	}
-	if val := os.Getenv("APRS_FILTER"); val != "" {
-		config.APRS.Filter = val
+// mergeConfigDir unmarshals every *.yaml fragment under dir, in filename
+// order, on top of config. Fragments are full or partial Config documents;
+// a field a fragment omits keeps whatever value an earlier layer set. dir
+// may be empty, in which case this is a no-op.
+func mergeConfigDir(config *Config, dir string) error {
+	if dir == "" {
+		return nil
 	}
 
-	// Database configuration
-	if val := os.Getenv("DATABASE_URL"); val != "" {
-		config.Database.URL = val
-	}
-	if val := os.Getenv("DATABASE_ADMIN_EMAIL"); val != "" {
-		config.Database.AdminEmail = val
-	}
-	if val := os.Getenv("DATABASE_ADMIN_PASSWORD"); val != "" {
-		config.Database.AdminPassword = val
+	matches, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to glob config-dir %q: %w", dir, err)
 	}
+	sort.Strings(matches)
 
-	// Logging configuration
-	if val := os.Getenv("LOG_LEVEL"); val != "" {
-		config.Logging.Level = strings.ToLower(val)
-	}
-	if val := os.Getenv("LOG_FORMAT"); val != "" {
-		config.Logging.Format = strings.ToLower(val)
-	}
+	for _, path := range matches {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read config fragment %q: %w", path, err)
+		}
 
-	// Services configuration
-	if val := os.Getenv("APRS_CONNECTOR_ENABLED"); val != "" {
-		config.Services.APRSConnector.Enabled = val == "true"
-	}
-	if val := os.Getenv("APRS_CONNECTOR_RECONNECT_DELAY"); val != "" {
-		if delay, err := strconv.Atoi(val); err == nil {
-			config.Services.APRSConnector.ReconnectDelay = delay
+		migrated, _, err := migrateConfigBytes(data)
+		if err != nil {
+			return fmt.Errorf("failed to migrate config fragment %q: %w", path, err)
+		}
+
+		if err := yaml.Unmarshal(migrated, config); err != nil {
+			return fmt.Errorf("failed to parse config fragment %q: %w", path, err)
 		}
 	}
+
+	return nil
 }
 
 // validateConfig validates the configuration
@@ -163,9 +479,8 @@ func validateConfig(config *Config) error {
 	if config.APRS.Callsign == "" {
 		return fmt.Errorf("APRS callsign is required")
 	}
-	if config.APRS.Passcode == "" {
-		return fmt.Errorf("APRS passcode is required")
-	}
+	// Passcode itself isn't validated here: resolveAPRSPasscode always
+	// fills it in, either from ReadOnly's "-1" or derived from Callsign.
 	if config.APRS.Server == "" {
 		return fmt.Errorf("APRS server is required")
 	}
@@ -193,9 +508,68 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("invalid log format: %s", config.Logging.Format)
 	}
 
+	validSinks := map[string]bool{
+		"stdout": true, "stderr": true, "file": true, "syslog": true, "http": true,
+	}
+	if config.Logging.Sink == "" {
+		config.Logging.Sink = config.Logging.Output
+	}
+	if !validSinks[config.Logging.Sink] {
+		return fmt.Errorf("invalid log sink: %s", config.Logging.Sink)
+	}
+	if config.Logging.Sink == "file" && config.Logging.File.Path == "" {
+		return fmt.Errorf("logging.file.path is required when logging.sink is 'file'")
+	}
+	if config.Logging.Sink == "http" && config.Logging.HTTP.URL == "" {
+		return fmt.Errorf("logging.http.url is required when logging.sink is 'http'")
+	}
+	if _, err := parsePackageLevels(config.Logging.PackageLevels); err != nil {
+		return fmt.Errorf("invalid logging.package_levels: %w", err)
+	}
+
+	validBackoffStrategies := map[string]bool{
+		"": true, "exponential": true, "fibonacci": true, "constant": true,
+	}
+	if !validBackoffStrategies[config.Services.APRSConnector.Retry.BackoffStrategy] {
+		return fmt.Errorf("invalid backoff strategy: %s", config.Services.APRSConnector.Retry.BackoffStrategy)
+	}
+
+	if config.Services.Metrics.Enabled && (config.Services.Metrics.Port <= 0 || config.Services.Metrics.Port > 65535) {
+		return fmt.Errorf("services.metrics.port must be between 1 and 65535")
+	}
+
+	// Validate IRC bridge configuration
+	if config.Services.IRC.Enabled {
+		if config.Services.IRC.Server == "" {
+			return fmt.Errorf("services.irc.server is required when IRC is enabled")
+		}
+		if config.Services.IRC.Channel == "" {
+			return fmt.Errorf("services.irc.channel is required when IRC is enabled")
+		}
+		if config.Services.IRC.Nick == "" {
+			return fmt.Errorf("services.irc.nick is required when IRC is enabled")
+		}
+	}
+
 	return nil
 }
 
+// resolveAPRSPasscode fills in APRS.Passcode when the operator hasn't
+// supplied one. ReadOnly always forces the receive-only login passcode
+// "-1" (the standard APRS-IS convention for a connection that never
+// transmits), overriding whatever was configured; otherwise an empty
+// passcode is derived from APRS.Callsign via GetAPRSPasscode.
+func resolveAPRSPasscode(config *Config) {
+	if config.APRS.ReadOnly {
+		config.APRS.Passcode = "-1"
+		return
+	}
+	if config.APRS.Passcode == "" {
+		config.APRS.Passcode = strconv.Itoa(GetAPRSPasscode(config.APRS.Callsign))
+		config.APRS.PasscodeDerived = true
+	}
+}
+
 // GetAPRSPasscode calculates APRS passcode for a given callsign
 func GetAPRSPasscode(callsign string) int {
 	callsign = strings.ToUpper(callsign)
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestGetAPRSPasscode(t *testing.T) {
+	tests := []struct {
+		callsign string
+		want     int
+	}{
+		{"N0CALL", 13023},
+		{"n0call", 13023}, // lowercase input is upper-cased before hashing
+		{"W4ABC", 9876},
+		{"VE3ABC", 21669},
+		{"KJ4ABC-9", 20138}, // SSID is stripped before hashing
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.callsign, func(t *testing.T) {
+			if got := GetAPRSPasscode(tt.callsign); got != tt.want {
+				t.Errorf("GetAPRSPasscode(%q) = %d, want %d", tt.callsign, got, tt.want)
+			}
+		})
+	}
+}
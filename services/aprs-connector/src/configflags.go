@@ -0,0 +1,237 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// bootstrapFlagNames are resolved directly by main before LoadConfig runs
+// and have no corresponding Config field or environment variable.
+var bootstrapFlagNames = map[string]bool{
+	"config":         true,
+	"config-dir":     true,
+	"config-overlay": true,
+	"migrate-config": true,
+	"version":        true,
+}
+
+// BuildFlagSet registers the CLI surface for the connector: the bootstrap
+// flags (config, config-dir, config-overlay, migrate-config, version) plus one flag per
+// Config field, so every key is overridable from the command line and
+// discoverable via --help. Flags default to their Go zero value;
+// applyFlagOverrides only applies a flag that was actually set (directly
+// on the command line, or indirectly via loadEnvOverrides), so an unset
+// flag never clobbers a value from the config file or environment with a
+// zero value. Fields that hold structured data (endpoint lists, routing
+// rules, per-path authorization) have no scalar CLI equivalent and are
+// configured via the YAML file, conf.d fragments, or --config-overlay only.
+func BuildFlagSet(name string) *pflag.FlagSet {
+	fs := pflag.NewFlagSet(name, pflag.ExitOnError)
+
+	fs.String("config", "/app/config/config.yaml", "Path to configuration file")
+	fs.String("config-dir", "", "Directory of conf.d-style *.yaml fragments merged over the config file, in filename order")
+	fs.String("config-overlay", "", "Optional .ini or .toml file merged over the config file and conf.d fragments, before environment and CLI overrides")
+	fs.Bool("migrate-config", false, "Write the config file back to disk, upgraded to the current schema version, keeping a .bak of the original")
+	fs.Bool("version", false, "Show version information")
+
+	fs.String("aprs.callsign", "", "APRS-IS callsign")
+	fs.String("aprs.passcode", "", "APRS-IS passcode")
+	fs.String("aprs.server", "", "APRS-IS server hostname")
+	fs.Int("aprs.port", 0, "APRS-IS server port")
+	fs.String("aprs.filter", "", "APRS-IS filter string")
+	fs.Int("aprs.beacon_interval", 0, "Beacon interval in seconds")
+	fs.Bool("aprs.read_only", false, "Log in receive-only, forcing passcode -1")
+	fs.Bool("aprs.tls", false, "Dial APRS-IS endpoints over TLS")
+	fs.Int("aprs.watchdog_seconds", 0, "Seconds of silence before the reconnect watchdog forces a reconnect")
+	fs.Int("aprs.endpoint_pool.cooldown_seconds", 0, "Seconds a failed endpoint is skipped before retrying")
+	fs.Int("aprs.endpoint_pool.max_backoff_seconds", 0, "Reconnect backoff cap in seconds, used only when retry.max_delay_seconds is 0")
+
+	fs.String("database.url", "", "PocketBase base URL")
+	fs.String("database.admin_email", "", "PocketBase admin email")
+	fs.String("database.admin_password", "", "PocketBase admin password")
+
+	fs.String("logging.level", "", "Log level (debug, info, warn, error)")
+	fs.String("logging.format", "", "Log format (json, text)")
+	fs.String("logging.output", "", "Deprecated alias for logging.sink")
+	fs.String("logging.sink", "", "Primary log sink (stdout, stderr, file, syslog, http)")
+	fs.String("logging.package_levels", "", "Per-subsystem log level overrides, e.g. aprs=debug,db=info")
+	fs.String("logging.file.path", "", "File sink log path")
+	fs.Int("logging.file.max_size", 0, "File sink max size in megabytes before rotation")
+	fs.Int("logging.file.max_age", 0, "File sink max age in days before deletion")
+	fs.Int("logging.file.max_backups", 0, "File sink max number of rotated backups to keep")
+	fs.Bool("logging.file.compress", false, "Gzip rotated file sink backups")
+	fs.String("logging.syslog.network", "", "Syslog network (\"\", tcp, or udp; empty means local syslog)")
+	fs.String("logging.syslog.address", "", "Syslog server address")
+	fs.String("logging.syslog.tag", "", "Syslog tag")
+	fs.String("logging.http.url", "", "HTTP sink webhook URL")
+	fs.Int("logging.http.buffer_size", 0, "HTTP sink buffered entry queue size")
+	fs.Int("logging.http.max_retries", 0, "HTTP sink max delivery retries")
+	fs.Int("logging.http.timeout_seconds", 0, "HTTP sink request timeout in seconds")
+
+	fs.Bool("services.aprs_connector.enabled", false, "Enable the APRS connector service")
+	fs.Int("services.aprs_connector.reconnect_delay", 0, "Legacy reconnect delay in seconds; not read, reconnect timing follows services.aprs_connector.retry")
+	fs.Int("services.aprs_connector.heartbeat_interval", 0, "Heartbeat status beacon interval in seconds")
+	fs.Int("services.aprs_connector.sweep_interval", 0, "Pending-message sweep interval in seconds; unacked-message retries run on the same tick")
+	fs.Int("services.aprs_connector.workers", 0, "Number of outbound message sender workers")
+	fs.Float64("services.aprs_connector.global_rate", 0, "Global outbound message rate limit, messages per second")
+	fs.Float64("services.aprs_connector.per_callsign_rate", 0, "Per-destination-callsign outbound rate limit, messages per second")
+	fs.Int("services.aprs_connector.retry.max_retries", 0, "Maximum retry attempts before giving up")
+	fs.Int("services.aprs_connector.retry.initial_delay_seconds", 0, "Initial retry delay in seconds")
+	fs.Int("services.aprs_connector.retry.max_delay_seconds", 0, "Maximum retry delay in seconds")
+	fs.Int("services.aprs_connector.retry.total_timeout_seconds", 0, "Total retry budget in seconds (0 means no limit)")
+	fs.Float64("services.aprs_connector.retry.jitter", 0, "Retry delay jitter fraction, 0-1")
+	fs.String("services.aprs_connector.retry.backoff_strategy", "", "Retry backoff strategy (exponential, fibonacci, constant)")
+
+	fs.Bool("services.irc.enabled", false, "Enable the IRC bridge")
+	fs.String("services.irc.server", "", "IRC server hostname")
+	fs.Int("services.irc.port", 0, "IRC server port")
+	fs.Bool("services.irc.tls", false, "Connect to the IRC server over TLS")
+	fs.String("services.irc.nick", "", "IRC bot nickname")
+	fs.String("services.irc.channel", "", "IRC channel to relay into")
+	fs.String("services.irc.nick_suffix", "", "Suffix appended to a relayed callsign to form its IRC nick")
+
+	fs.Bool("services.metrics.enabled", false, "Enable the metrics/events HTTP server")
+	fs.Int("services.metrics.port", 0, "Metrics/events HTTP server port")
+	fs.String("services.metrics.events_token", "", "Bearer token WebSocket clients must present to subscribe to /events")
+
+	return fs
+}
+
+// envNameForFlag derives the environment variable name that mirrors a
+// registered flag, e.g. "aprs.callsign" becomes "APRS_CALLSIGN". This is
+// how loadEnvOverrides covers every flag in BuildFlagSet automatically,
+// instead of a hand-written block of os.Getenv calls per key.
+func envNameForFlag(name string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(name))
+}
+
+// loadEnvOverrides applies an environment variable override for every
+// registered flag that wasn't already set explicitly on the command line,
+// using envNameForFlag to derive the variable name. Setting the flag (via
+// fs.Set) rather than writing to config directly marks it Changed, so
+// applyFlagOverrides picks it up the same way it would a CLI flag - one
+// mechanism instead of two. fs may be nil, in which case this is a no-op.
+func loadEnvOverrides(fs *pflag.FlagSet) {
+	if fs == nil {
+		return
+	}
+	fs.VisitAll(func(f *pflag.Flag) {
+		if bootstrapFlagNames[f.Name] || fs.Changed(f.Name) {
+			return
+		}
+		val, ok := os.LookupEnv(envNameForFlag(f.Name))
+		if !ok {
+			return
+		}
+		// Ignore a malformed value rather than aborting startup; the field
+		// keeps whatever the file/default layer set, same as an unset flag.
+		_ = fs.Set(f.Name, val)
+	})
+}
+
+// applyFlagOverrides copies every explicitly-set flag in fs onto config.
+// fs may be nil, in which case this is a no-op.
+func applyFlagOverrides(config *Config, fs *pflag.FlagSet) {
+	if fs == nil {
+		return
+	}
+
+	str := func(name string, dst *string) {
+		if fs.Changed(name) {
+			v, _ := fs.GetString(name)
+			*dst = v
+		}
+	}
+	upperStr := func(name string, dst *string) {
+		if fs.Changed(name) {
+			v, _ := fs.GetString(name)
+			*dst = strings.ToUpper(v)
+		}
+	}
+	lowerStr := func(name string, dst *string) {
+		if fs.Changed(name) {
+			v, _ := fs.GetString(name)
+			*dst = strings.ToLower(v)
+		}
+	}
+	intVal := func(name string, dst *int) {
+		if fs.Changed(name) {
+			v, _ := fs.GetInt(name)
+			*dst = v
+		}
+	}
+	floatVal := func(name string, dst *float64) {
+		if fs.Changed(name) {
+			v, _ := fs.GetFloat64(name)
+			*dst = v
+		}
+	}
+	boolVal := func(name string, dst *bool) {
+		if fs.Changed(name) {
+			v, _ := fs.GetBool(name)
+			*dst = v
+		}
+	}
+
+	upperStr("aprs.callsign", &config.APRS.Callsign)
+	str("aprs.passcode", &config.APRS.Passcode)
+	str("aprs.server", &config.APRS.Server)
+	intVal("aprs.port", &config.APRS.Port)
+	str("aprs.filter", &config.APRS.Filter)
+	intVal("aprs.beacon_interval", &config.APRS.BeaconInterval)
+	boolVal("aprs.read_only", &config.APRS.ReadOnly)
+	boolVal("aprs.tls", &config.APRS.TLS)
+	intVal("aprs.watchdog_seconds", &config.APRS.WatchdogSeconds)
+	intVal("aprs.endpoint_pool.cooldown_seconds", &config.APRS.EndpointPool.CooldownSeconds)
+	intVal("aprs.endpoint_pool.max_backoff_seconds", &config.APRS.EndpointPool.MaxBackoff)
+
+	str("database.url", &config.Database.URL)
+	str("database.admin_email", &config.Database.AdminEmail)
+	str("database.admin_password", &config.Database.AdminPassword)
+
+	lowerStr("logging.level", &config.Logging.Level)
+	lowerStr("logging.format", &config.Logging.Format)
+	lowerStr("logging.output", &config.Logging.Output)
+	lowerStr("logging.sink", &config.Logging.Sink)
+	str("logging.package_levels", &config.Logging.PackageLevels)
+	str("logging.file.path", &config.Logging.File.Path)
+	intVal("logging.file.max_size", &config.Logging.File.MaxSize)
+	intVal("logging.file.max_age", &config.Logging.File.MaxAge)
+	intVal("logging.file.max_backups", &config.Logging.File.MaxBackups)
+	boolVal("logging.file.compress", &config.Logging.File.Compress)
+	lowerStr("logging.syslog.network", &config.Logging.Syslog.Network)
+	str("logging.syslog.address", &config.Logging.Syslog.Address)
+	str("logging.syslog.tag", &config.Logging.Syslog.Tag)
+	str("logging.http.url", &config.Logging.HTTP.URL)
+	intVal("logging.http.buffer_size", &config.Logging.HTTP.BufferSize)
+	intVal("logging.http.max_retries", &config.Logging.HTTP.MaxRetries)
+	intVal("logging.http.timeout_seconds", &config.Logging.HTTP.TimeoutSec)
+
+	boolVal("services.aprs_connector.enabled", &config.Services.APRSConnector.Enabled)
+	intVal("services.aprs_connector.reconnect_delay", &config.Services.APRSConnector.ReconnectDelay)
+	intVal("services.aprs_connector.heartbeat_interval", &config.Services.APRSConnector.HeartbeatInterval)
+	intVal("services.aprs_connector.sweep_interval", &config.Services.APRSConnector.SweepInterval)
+	intVal("services.aprs_connector.workers", &config.Services.APRSConnector.Workers)
+	floatVal("services.aprs_connector.global_rate", &config.Services.APRSConnector.GlobalRate)
+	floatVal("services.aprs_connector.per_callsign_rate", &config.Services.APRSConnector.PerCallsignRate)
+	intVal("services.aprs_connector.retry.max_retries", &config.Services.APRSConnector.Retry.MaxRetries)
+	intVal("services.aprs_connector.retry.initial_delay_seconds", &config.Services.APRSConnector.Retry.InitialDelaySeconds)
+	intVal("services.aprs_connector.retry.max_delay_seconds", &config.Services.APRSConnector.Retry.MaxDelaySeconds)
+	intVal("services.aprs_connector.retry.total_timeout_seconds", &config.Services.APRSConnector.Retry.TotalTimeoutSeconds)
+	floatVal("services.aprs_connector.retry.jitter", &config.Services.APRSConnector.Retry.Jitter)
+	lowerStr("services.aprs_connector.retry.backoff_strategy", &config.Services.APRSConnector.Retry.BackoffStrategy)
+
+	boolVal("services.irc.enabled", &config.Services.IRC.Enabled)
+	str("services.irc.server", &config.Services.IRC.Server)
+	intVal("services.irc.port", &config.Services.IRC.Port)
+	boolVal("services.irc.tls", &config.Services.IRC.TLS)
+	str("services.irc.nick", &config.Services.IRC.Nick)
+	str("services.irc.channel", &config.Services.IRC.Channel)
+	str("services.irc.nick_suffix", &config.Services.IRC.NickSuffix)
+
+	boolVal("services.metrics.enabled", &config.Services.Metrics.Enabled)
+	intVal("services.metrics.port", &config.Services.Metrics.Port)
+	str("services.metrics.events_token", &config.Services.Metrics.EventsToken)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ConfigHolder lets long-lived consumers read the current configuration
+// and be notified when it changes, without having to restart the process.
+// Only Config.Paths is expected to actually change shape meaningfully at
+// runtime today (connection-level settings like APRS.Server take effect on
+// the next reconnect regardless), but the whole config is swapped so future
+// reloadable fields don't need a second mechanism.
+type ConfigHolder struct {
+	mu            sync.RWMutex
+	current       *Config
+	path          string
+	configDir     string
+	configOverlay string
+	logger        *logrus.Logger
+	callbacks     []func(*Config)
+}
+
+// NewConfigHolder wraps an already-loaded config for path so it can be
+// reloaded later. configDir and configOverlay are remembered so a reload
+// re-applies the same conf.d fragments and optional .ini/.toml overlay;
+// CLI flags are intentionally not re-applied on reload, since they
+// reflect how the process was launched, not the file.
+func NewConfigHolder(path, configDir, configOverlay string, initial *Config, logger *logrus.Logger) *ConfigHolder {
+	return &ConfigHolder{
+		current:       initial,
+		path:          path,
+		configDir:     configDir,
+		configOverlay: configOverlay,
+		logger:        logger,
+	}
+}
+
+// Get returns the current configuration. Callers should re-fetch rather
+// than cache the result across a reload boundary.
+func (h *ConfigHolder) Get() *Config {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.current
+}
+
+// OnReload registers a callback invoked with the new config after a
+// successful Reload. Callbacks run synchronously in Reload's caller.
+func (h *ConfigHolder) OnReload(cb func(*Config)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks = append(h.callbacks, cb)
+}
+
+// Reload re-reads and validates the config file, swapping it in only if
+// valid so a bad edit can't take the service down.
+func (h *ConfigHolder) Reload() error {
+	next, err := LoadConfig(h.path, h.configDir, h.configOverlay, nil, false)
+	if err != nil {
+		return fmt.Errorf("failed to reload config: %w", err)
+	}
+
+	h.mu.Lock()
+	h.current = next
+	callbacks := append([]func(*Config){}, h.callbacks...)
+	h.mu.Unlock()
+
+	for _, cb := range callbacks {
+		cb(next)
+	}
+
+	return nil
+}
+
+// WatchSIGHUP reloads the config every time the process receives SIGHUP,
+// logging the outcome either way.
+func (h *ConfigHolder) WatchSIGHUP() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+
+	go func() {
+		for range sigChan {
+			h.logger.Info("Received SIGHUP, reloading configuration")
+			if err := h.Reload(); err != nil {
+				h.logger.WithError(err).Error("Failed to reload configuration")
+				continue
+			}
+			h.logger.Info("Configuration reloaded")
+		}
+	}()
+}
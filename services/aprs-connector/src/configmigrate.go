@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchemaVersion is the version LoadConfig migrates on-disk config
+// up to before decoding it into Config. Bump this and append a migration
+// to configMigrations whenever a change to Config's shape would otherwise
+// break older config files.
+const CurrentSchemaVersion = 1
+
+// configMigration upgrades a decoded config document by exactly one schema
+// version. raw is the document decoded as a generic map so a migration can
+// move or rename keys without needing Config's typed fields to still exist
+// on either side of the change.
+type configMigration func(raw map[string]interface{}) (map[string]interface{}, error)
+
+// configMigrations is ordered by source version: configMigrations[i]
+// upgrades version i to i+1. migrateConfig applies them in order starting
+// from the document's current version.
+var configMigrations = []configMigration{
+	migrateV0ToV1,
+}
+
+// migrateV0ToV1 moves the original unversioned schema's top-level
+// aprs.reconnect_delay to services.aprs_connector.reconnect_delay, which
+// is where every other APRS connector tuning knob (workers, rates, retry)
+// already lives.
+func migrateV0ToV1(raw map[string]interface{}) (map[string]interface{}, error) {
+	aprs, _ := raw["aprs"].(map[string]interface{})
+	if aprs != nil {
+		if delay, ok := aprs["reconnect_delay"]; ok {
+			services, _ := raw["services"].(map[string]interface{})
+			if services == nil {
+				services = map[string]interface{}{}
+				raw["services"] = services
+			}
+			connector, _ := services["aprs_connector"].(map[string]interface{})
+			if connector == nil {
+				connector = map[string]interface{}{}
+				services["aprs_connector"] = connector
+			}
+			connector["reconnect_delay"] = delay
+			delete(aprs, "reconnect_delay")
+		}
+	}
+
+	raw["version"] = 1
+	return raw, nil
+}
+
+// migrateConfig runs raw through every configMigration needed to bring it
+// from its current "version" field (missing or non-numeric means 0) up to
+// CurrentSchemaVersion, returning the upgraded document and whether any
+// migration actually ran.
+func migrateConfig(raw map[string]interface{}) (map[string]interface{}, bool, error) {
+	version := 0
+	if v, ok := raw["version"]; ok {
+		if n, ok := v.(int); ok {
+			version = n
+		}
+	}
+
+	if version < 0 || version > len(configMigrations) {
+		return nil, false, fmt.Errorf("unsupported config schema version %d", version)
+	}
+
+	changed := false
+	for version < CurrentSchemaVersion {
+		migrate := configMigrations[version]
+		next, err := migrate(raw)
+		if err != nil {
+			return nil, false, fmt.Errorf("migrating config from version %d: %w", version, err)
+		}
+		raw = next
+		changed = true
+		version++
+	}
+
+	return raw, changed, nil
+}
+
+// migrateConfigBytes decodes data as a generic YAML document, runs it
+// through migrateConfig, and re-encodes the result. If data decodes to no
+// map at all (e.g. an empty file), it is returned unchanged with changed set
+// to false, since there is nothing to migrate; a document that is not a map
+// (e.g. a list) is an error.
+func migrateConfigBytes(data []byte) (migrated []byte, changed bool, err error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, false, fmt.Errorf("failed to parse config as YAML document: %w", err)
+	}
+	if raw == nil {
+		return data, false, nil
+	}
+
+	raw, changed, err = migrateConfig(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	if !changed {
+		return data, false, nil
+	}
+
+	out, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to re-encode migrated config: %w", err)
+	}
+	return out, true, nil
+}
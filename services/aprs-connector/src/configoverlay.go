@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/pflag"
+	"gopkg.in/ini.v1"
+)
+
+// loadConfigOverlay merges an optional .ini or .toml file over the config
+// file and conf.d fragments, layered just below environment variables and
+// CLI flags (see LoadConfig). Unlike the YAML layers, which decode
+// directly into Config, the overlay is flattened into "section.key"
+// dot-paths and applied through the same flag set as
+// loadEnvOverrides/applyFlagOverrides, so it only ever reaches scalar
+// fields that already have a registered flag - the same constraint CLI
+// flags and environment variables are under. path may be empty, in which
+// case this is a no-op.
+func loadConfigOverlay(path string, fs *pflag.FlagSet) error {
+	if path == "" || fs == nil {
+		return nil
+	}
+
+	var flat map[string]string
+	var err error
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".ini":
+		flat, err = flattenINI(path)
+	case ".toml":
+		flat, err = flattenTOML(path)
+	default:
+		return fmt.Errorf("unsupported config overlay extension %q (expected .ini or .toml)", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config overlay %q: %w", path, err)
+	}
+
+	for key, val := range flat {
+		if fs.Lookup(key) == nil || fs.Changed(key) {
+			continue
+		}
+		if err := fs.Set(key, val); err != nil {
+			return fmt.Errorf("config overlay %q: invalid value for %s: %w", path, key, err)
+		}
+	}
+	return nil
+}
+
+// flattenINI loads an INI file and flattens it into "section.key"
+// dot-paths matching the flag names in BuildFlagSet, e.g.
+// "[aprs]\ncallsign = W1AW" becomes the key "aprs.callsign" = "W1AW". Keys
+// outside any section are flattened as bare names.
+func flattenINI(path string) (map[string]string, error) {
+	cfg, err := ini.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	flat := make(map[string]string)
+	for _, section := range cfg.Sections() {
+		prefix := ""
+		if section.Name() != ini.DefaultSection {
+			prefix = strings.ToLower(section.Name()) + "."
+		}
+		for _, key := range section.Keys() {
+			flat[prefix+strings.ToLower(key.Name())] = key.Value()
+		}
+	}
+	return flat, nil
+}
+
+// flattenTOML loads a TOML file and flattens nested tables into
+// "table.key" dot-paths the same way flattenINI does for INI sections.
+func flattenTOML(path string) (map[string]string, error) {
+	var raw map[string]interface{}
+	if _, err := toml.DecodeFile(path, &raw); err != nil {
+		return nil, err
+	}
+
+	flat := make(map[string]string)
+	flattenTOMLValue("", raw, flat)
+	return flat, nil
+}
+
+func flattenTOMLValue(prefix string, val interface{}, flat map[string]string) {
+	table, ok := val.(map[string]interface{})
+	if !ok {
+		flat[prefix] = fmt.Sprintf("%v", val)
+		return
+	}
+	for k, nested := range table {
+		key := strings.ToLower(k)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		flattenTOMLValue(key, nested, flat)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -14,10 +15,52 @@ import (
 
 // DatabaseClient handles communication with PocketBase
 type DatabaseClient struct {
+	configMu   sync.RWMutex // guards config against concurrent reads and an OnReload swap
 	config     *Config
 	logger     *logrus.Logger
 	baseURL    string
 	httpClient *http.Client
+
+	authMu        sync.RWMutex
+	token         string
+	tokenIssuedAt time.Time
+	authInFlight  chan struct{} // non-nil while a re-auth is in progress; closed when it completes
+}
+
+// Config returns the client's current configuration, safe to call
+// concurrently with a reload swapping it out.
+func (db *DatabaseClient) Config() *Config {
+	db.configMu.RLock()
+	defer db.configMu.RUnlock()
+	return db.config
+}
+
+// SetConfig swaps in a new configuration, e.g. after a SIGHUP reload.
+func (db *DatabaseClient) SetConfig(config *Config) {
+	db.configMu.Lock()
+	defer db.configMu.Unlock()
+	db.config = config
+}
+
+// defaultAuthTokenTTL is PocketBase's default admin token lifetime. The
+// auth response doesn't return an expiry, so this is an estimate used only
+// for the rarsms_db_auth_token_ttl_seconds gauge.
+const defaultAuthTokenTTL = 7 * 24 * time.Hour
+
+// TokenTTLRemaining estimates how long the cached auth token has left,
+// assuming defaultAuthTokenTTL from when it was issued.
+func (db *DatabaseClient) TokenTTLRemaining() time.Duration {
+	db.authMu.RLock()
+	defer db.authMu.RUnlock()
+
+	if db.token == "" {
+		return 0
+	}
+	remaining := defaultAuthTokenTTL - time.Since(db.tokenIssuedAt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
 }
 
 // NewDatabaseClient creates a new database client
@@ -32,8 +75,98 @@ func NewDatabaseClient(config *Config, logger *logrus.Logger) *DatabaseClient {
 	}
 }
 
-// makeRequest makes an HTTP request to PocketBase API
+// Authenticate logs in as the configured admin/service account and caches
+// the returned JWT for subsequent requests. Call once at startup; makeRequest
+// re-authenticates automatically if a request later comes back 401.
+func (db *DatabaseClient) Authenticate() error {
+	if db.Config().Database.AdminEmail == "" || db.Config().Database.AdminPassword == "" {
+		db.logger.Debug("No database admin credentials configured, requests will be unauthenticated")
+		return nil
+	}
+	return db.reauthenticate()
+}
+
+// reauthenticate performs the actual login call. Concurrent callers block
+// on the same in-flight attempt instead of each firing their own login
+// request (a "single-flight" guard).
+func (db *DatabaseClient) reauthenticate() error {
+	db.authMu.Lock()
+	if db.authInFlight != nil {
+		inFlight := db.authInFlight
+		db.authMu.Unlock()
+		<-inFlight
+		return nil
+	}
+	inFlight := make(chan struct{})
+	db.authInFlight = inFlight
+	db.authMu.Unlock()
+
+	defer func() {
+		db.authMu.Lock()
+		db.authInFlight = nil
+		db.authMu.Unlock()
+		close(inFlight)
+	}()
+
+	payload := map[string]string{
+		"identity": db.Config().Database.AdminEmail,
+		"password": db.Config().Database.AdminPassword,
+	}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal auth request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/admins/auth-with-password", db.baseURL)
+	resp, err := db.httpClient.Post(url, "application/json", bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to authenticate with database: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("database auth failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode auth response: %w", err)
+	}
+
+	db.authMu.Lock()
+	db.token = result.Token
+	db.tokenIssuedAt = time.Now()
+	db.authMu.Unlock()
+
+	metrics.authTokenTTL.Set(defaultAuthTokenTTL.Seconds())
+	db.logger.Debug("Authenticated with database")
+	return nil
+}
+
+// makeRequest makes an HTTP request to PocketBase API, attaching the cached
+// auth token if one is set and transparently re-authenticating once on 401.
 func (db *DatabaseClient) makeRequest(method, endpoint string, body interface{}) (*http.Response, error) {
+	resp, err := db.doRequest(method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		if authErr := db.reauthenticate(); authErr != nil {
+			return nil, fmt.Errorf("request unauthorized and re-auth failed: %w", authErr)
+		}
+		return db.doRequest(method, endpoint, body)
+	}
+
+	return resp, nil
+}
+
+// doRequest performs a single HTTP round trip with the current auth token attached.
+func (db *DatabaseClient) doRequest(method, endpoint string, body interface{}) (*http.Response, error) {
 	var reqBody *bytes.Buffer
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -53,7 +186,16 @@ func (db *DatabaseClient) makeRequest(method, endpoint string, body interface{})
 
 	req.Header.Set("Content-Type", "application/json")
 
+	db.authMu.RLock()
+	token := db.token
+	db.authMu.RUnlock()
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	start := time.Now()
 	resp, err := db.httpClient.Do(req)
+	metrics.dbCallLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -61,214 +203,173 @@ func (db *DatabaseClient) makeRequest(method, endpoint string, body interface{})
 	return resp, nil
 }
 
-// IsAuthorizedMember checks if a callsign is an authorized member
-func (db *DatabaseClient) IsAuthorizedMember(callsign string) (bool, error) {
-	callsign = strings.ToUpper(callsign)
-
-	// Query member_profiles collection for the callsign
-	endpoint := fmt.Sprintf("member_profiles/records?filter=callsign='%s'", callsign)
-	resp, err := db.makeRequest("GET", endpoint, nil)
+// Ping performs a lightweight round trip against PocketBase and returns how
+// long it took, for use by health checks.
+func (db *DatabaseClient) Ping() (time.Duration, error) {
+	start := time.Now()
+	resp, err := db.makeRequest("GET", "system_status/records?perPage=1", nil)
 	if err != nil {
-		return false, fmt.Errorf("failed to query member: %w", err)
+		return time.Since(start), fmt.Errorf("failed to reach database: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == 404 {
-		return false, nil // Member not found
+	if resp.StatusCode >= 500 {
+		return time.Since(start), fmt.Errorf("database returned status %d", resp.StatusCode)
 	}
 
-	if resp.StatusCode != 200 {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return false, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
-	}
+	return time.Since(start), nil
+}
 
-	var result struct {
-		Items []map[string]interface{} `json:"items"`
+// ServerTime returns the current time as reported by the PocketBase HTTP
+// server's Date header, used as a lightweight clock-skew reference.
+func (db *DatabaseClient) ServerTime() (time.Time, error) {
+	resp, err := db.makeRequest("GET", "system_status/records?perPage=1", nil)
+	if err != nil {
+		return time.Time{}, err
 	}
+	defer resp.Body.Close()
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return false, fmt.Errorf("failed to decode response: %w", err)
+	dateHeader := resp.Header.Get("Date")
+	if dateHeader == "" {
+		return time.Time{}, fmt.Errorf("server response did not include a Date header")
 	}
 
-	// Check if member found and get associated user
-	if len(result.Items) == 0 {
-		return false, nil
-	}
+	return http.ParseTime(dateHeader)
+}
 
-	memberProfile := result.Items[0]
-	userID, ok := memberProfile["user"].(string)
-	if !ok {
-		return false, fmt.Errorf("invalid user ID in member profile")
-	}
+// memberProfileByCallsign looks up the member_profiles record for callsign,
+// returning (zero value, nil) if no member is found.
+func (db *DatabaseClient) memberProfileByCallsign(callsign string) (MemberProfile, error) {
+	callsign = strings.ToUpper(callsign)
 
-	// Check if user is approved and active
-	userResp, err := db.makeRequest("GET", fmt.Sprintf("users/records/%s", userID), nil)
+	profiles, err := listTyped[MemberProfile](db, "member_profiles", fmt.Sprintf("filter=callsign='%s'", callsign))
 	if err != nil {
-		return false, fmt.Errorf("failed to query user: %w", err)
+		return MemberProfile{}, fmt.Errorf("failed to query member: %w", err)
+	}
+	if len(profiles) == 0 {
+		return MemberProfile{}, nil
 	}
-	defer userResp.Body.Close()
+	return profiles[0], nil
+}
 
-	if userResp.StatusCode != 200 {
+// IsAuthorizedMember checks if a callsign is an authorized member
+func (db *DatabaseClient) IsAuthorizedMember(callsign string) (bool, error) {
+	profile, err := db.memberProfileByCallsign(callsign)
+	if err != nil {
+		return false, err
+	}
+	if profile.User == "" {
 		return false, nil
 	}
 
-	var user map[string]interface{}
-	if err := json.NewDecoder(userResp.Body).Decode(&user); err != nil {
-		return false, fmt.Errorf("failed to decode user response: %w", err)
+	user, err := getTyped[User](db, "users", profile.User)
+	if err != nil {
+		return false, nil // Treat an unreadable/missing user as unauthorized rather than an error
 	}
 
-	approved, _ := user["approved"].(bool)
-	return approved, nil
+	return user.Approved, nil
 }
 
 // GetUserIDByCallsign gets the user ID for a given callsign
 func (db *DatabaseClient) GetUserIDByCallsign(callsign string) (string, error) {
-	callsign = strings.ToUpper(callsign)
-
-	endpoint := fmt.Sprintf("member_profiles/records?filter=callsign='%s'", callsign)
-	resp, err := db.makeRequest("GET", endpoint, nil)
+	profile, err := db.memberProfileByCallsign(callsign)
 	if err != nil {
-		return "", fmt.Errorf("failed to query member: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return "", nil // Member not found
-	}
-
-	var result struct {
-		Items []map[string]interface{} `json:"items"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if len(result.Items) == 0 {
-		return "", nil
-	}
-
-	userID, ok := result.Items[0]["user"].(string)
-	if !ok {
-		return "", fmt.Errorf("invalid user ID in member profile")
+		return "", err
 	}
-
-	return userID, nil
+	return profile.User, nil
 }
 
 // CreateMessage stores a new message in the database
-func (db *DatabaseClient) CreateMessage(messageData map[string]interface{}) error {
-	resp, err := db.makeRequest("POST", "messages/records", messageData)
-	if err != nil {
+func (db *DatabaseClient) CreateMessage(message Message) error {
+	if _, err := createTyped(db, "messages", message); err != nil {
 		return fmt.Errorf("failed to create message: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 && resp.StatusCode != 201 {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
-	}
 
-	db.logger.WithField("correlation_id", messageData["correlation_id"]).Debug("Message created in database")
+	db.logger.WithField("correlation_id", message.CorrelationID).Debug("Message created in database")
 	return nil
 }
 
 // CreateAPRSPacket stores a raw APRS packet
-func (db *DatabaseClient) CreateAPRSPacket(packetData map[string]interface{}) error {
-	resp, err := db.makeRequest("POST", "aprs_packets/records", packetData)
-	if err != nil {
-		return fmt.Errorf("failed to create APRS packet: %w", err)
-	}
-	defer resp.Body.Close()
+func (db *DatabaseClient) CreateAPRSPacket(packet APRSPacket) error {
+	_, err := createTyped(db, "aprs_packets", packet)
+	return err
+}
 
-	if resp.StatusCode != 200 && resp.StatusCode != 201 {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
-	}
+// CreatePosition stores a decoded APRS position report
+func (db *DatabaseClient) CreatePosition(position PositionRecord) error {
+	_, err := createTyped(db, "aprs_positions", position)
+	return err
+}
 
-	return nil
+// CreateWeatherReport stores a decoded APRS weather report
+func (db *DatabaseClient) CreateWeatherReport(weather WeatherRecord) error {
+	_, err := createTyped(db, "aprs_weather", weather)
+	return err
+}
+
+// CreateTelemetry stores a decoded APRS telemetry report
+func (db *DatabaseClient) CreateTelemetry(telemetry TelemetryRecord) error {
+	_, err := createTyped(db, "aprs_telemetry", telemetry)
+	return err
+}
+
+// CreateObject stores a decoded APRS object or item report
+func (db *DatabaseClient) CreateObject(object ObjectRecord) error {
+	_, err := createTyped(db, "aprs_objects", object)
+	return err
+}
+
+// CreateStatus stores a decoded APRS status report
+func (db *DatabaseClient) CreateStatus(status StatusRecord) error {
+	_, err := createTyped(db, "aprs_status", status)
+	return err
 }
 
 // LogEvent creates a system log entry
 func (db *DatabaseClient) LogEvent(level, service, eventType, message string, metadata map[string]interface{}, correlationID string) error {
-	logData := map[string]interface{}{
-		"level":       level,
-		"service":     service,
-		"event_type":  eventType,
-		"message":     message,
-		"metadata":    metadata,
+	logData := SystemLog{
+		Level:         level,
+		Service:       service,
+		EventType:     eventType,
+		Message:       message,
+		Metadata:      metadata,
+		CorrelationID: correlationID,
 	}
 
-	if correlationID != "" {
-		logData["correlation_id"] = correlationID
-	}
-
-	resp, err := db.makeRequest("POST", "system_logs/records", logData)
-	if err != nil {
+	if _, err := createTyped(db, "system_logs", logData); err != nil {
 		return fmt.Errorf("failed to create log: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 && resp.StatusCode != 201 {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
-	}
 
 	return nil
 }
 
 // UpdateSystemStatus updates the system status for a service
 func (db *DatabaseClient) UpdateSystemStatus(service, status string, metadata map[string]interface{}) error {
-	// First try to get existing record
-	endpoint := fmt.Sprintf("system_status/records?filter=service='%s'", service)
-	resp, err := db.makeRequest("GET", endpoint, nil)
-	if err != nil {
-		return fmt.Errorf("failed to query system status: %w", err)
+	statusData := SystemStatus{
+		Service:       service,
+		Status:        status,
+		LastHeartbeat: time.Now().Format(time.RFC3339),
+		Metadata:      metadata,
 	}
-	defer resp.Body.Close()
+	events.publish("status", statusData)
 
-	statusData := map[string]interface{}{
-		"service":        service,
-		"status":         status,
-		"last_heartbeat": time.Now().Format(time.RFC3339),
-		"metadata":       metadata,
+	// First try to find an existing record for this service
+	existing, err := listTyped[SystemStatus](db, "system_status", fmt.Sprintf("filter=service='%s'", service))
+	if err != nil {
+		return fmt.Errorf("failed to query system status: %w", err)
 	}
 
-	if resp.StatusCode == 200 {
-		// Parse existing records
-		var result struct {
-			Items []map[string]interface{} `json:"items"`
-		}
-
-		respBody, _ := ioutil.ReadAll(resp.Body)
-		if err := json.Unmarshal(respBody, &result); err == nil && len(result.Items) > 0 {
-			// Update existing record
-			recordID := result.Items[0]["id"].(string)
-			updateResp, err := db.makeRequest("PATCH", fmt.Sprintf("system_status/records/%s", recordID), statusData)
-			if err != nil {
-				return fmt.Errorf("failed to update system status: %w", err)
-			}
-			defer updateResp.Body.Close()
-
-			if updateResp.StatusCode != 200 {
-				body, _ := ioutil.ReadAll(updateResp.Body)
-				return fmt.Errorf("API error %d: %s", updateResp.StatusCode, string(body))
-			}
-			return nil
+	if len(existing) > 0 {
+		if err := updateTyped(db, "system_status", existing[0].ID, statusData); err != nil {
+			return fmt.Errorf("failed to update system status: %w", err)
 		}
+		return nil
 	}
 
 	// Create new record if none exists
-	createResp, err := db.makeRequest("POST", "system_status/records", statusData)
-	if err != nil {
+	if _, err := createTyped(db, "system_status", statusData); err != nil {
 		return fmt.Errorf("failed to create system status: %w", err)
 	}
-	defer createResp.Body.Close()
-
-	if createResp.StatusCode != 200 && createResp.StatusCode != 201 {
-		body, _ := ioutil.ReadAll(createResp.Body)
-		return fmt.Errorf("API error %d: %s", createResp.StatusCode, string(body))
-	}
 
 	return nil
 }
@@ -280,118 +381,77 @@ func (db *DatabaseClient) CreateOrUpdateConversation(correlationID, userID, subj
 		subject = subject[:47] + "..."
 	}
 
-	// Try to find existing conversation
-	endpoint := fmt.Sprintf("conversations/records?filter=correlation_id='%s'", correlationID)
-	resp, err := db.makeRequest("GET", endpoint, nil)
-	if err != nil {
-		return fmt.Errorf("failed to query conversation: %w", err)
-	}
-	defer resp.Body.Close()
-
-	conversationData := map[string]interface{}{
-		"correlation_id":    correlationID,
-		"services_involved": []string{"aprs", "discord"},
-		"subject":          subject,
-		"status":           "active",
-		"last_activity":    time.Now().Format(time.RFC3339),
-		"message_count":    1,
+	conversationData := Conversation{
+		CorrelationID:    correlationID,
+		ServicesInvolved: []string{"aprs", "discord"},
+		Subject:          subject,
+		Status:           "active",
+		LastActivity:     time.Now().Format(time.RFC3339),
+		MessageCount:     1,
+		InitiatedBy:      userID,
 	}
 
-	if userID != "" {
-		conversationData["initiated_by"] = userID
+	// Try to find an existing conversation for this correlation ID
+	existing, err := listTyped[Conversation](db, "conversations", fmt.Sprintf("filter=correlation_id='%s'", correlationID))
+	if err != nil {
+		return fmt.Errorf("failed to query conversation: %w", err)
 	}
 
-	if resp.StatusCode == 200 {
-		// Parse existing records
-		var result struct {
-			Items []map[string]interface{} `json:"items"`
-		}
-
-		respBody, _ := ioutil.ReadAll(resp.Body)
-		if err := json.Unmarshal(respBody, &result); err == nil && len(result.Items) > 0 {
-			// Update existing conversation
-			recordID := result.Items[0]["id"].(string)
-			existingCount, _ := result.Items[0]["message_count"].(float64)
-			conversationData["message_count"] = int(existingCount) + 1
-
-			updateResp, err := db.makeRequest("PATCH", fmt.Sprintf("conversations/records/%s", recordID), conversationData)
-			if err != nil {
-				return fmt.Errorf("failed to update conversation: %w", err)
-			}
-			defer updateResp.Body.Close()
-
-			if updateResp.StatusCode != 200 {
-				body, _ := ioutil.ReadAll(updateResp.Body)
-				return fmt.Errorf("API error %d: %s", updateResp.StatusCode, string(body))
-			}
-			return nil
+	if len(existing) > 0 {
+		conversationData.MessageCount = existing[0].MessageCount + 1
+		if err := updateTyped(db, "conversations", existing[0].ID, conversationData); err != nil {
+			return fmt.Errorf("failed to update conversation: %w", err)
 		}
+		return nil
 	}
 
 	// Create new conversation
-	createResp, err := db.makeRequest("POST", "conversations/records", conversationData)
-	if err != nil {
+	if _, err := createTyped(db, "conversations", conversationData); err != nil {
 		return fmt.Errorf("failed to create conversation: %w", err)
 	}
-	defer createResp.Body.Close()
-
-	if createResp.StatusCode != 200 && createResp.StatusCode != 201 {
-		body, _ := ioutil.ReadAll(createResp.Body)
-		return fmt.Errorf("API error %d: %s", createResp.StatusCode, string(body))
-	}
 
 	return nil
 }
 
-// GetPendingMessages retrieves messages pending delivery to APRS
-func (db *DatabaseClient) GetPendingMessages() ([]map[string]interface{}, error) {
-	endpoint := "messages/records?filter=to_service='aprs'%20%26%26%20status='pending'&sort=-created"
-	resp, err := db.makeRequest("GET", endpoint, nil)
+// GetPendingMessages retrieves messages pending delivery to APRS. It is a
+// thin wrapper around GetPendingMessagesFor kept for the existing APRS
+// sender loop.
+func (db *DatabaseClient) GetPendingMessages() ([]Message, error) {
+	return db.GetPendingMessagesFor("aprs")
+}
+
+// GetPendingMessagesFor retrieves messages pending delivery to the named
+// bridge, i.e. those whose routes array contains it and whose status is
+// "pending" or "deferred" (held back by a failed preflight check).
+func (db *DatabaseClient) GetPendingMessagesFor(service string) ([]Message, error) {
+	query := fmt.Sprintf("filter=routes~'%s'%%20%%26%%26%%20%%28status='pending'%%20%%7C%%7C%%20status='deferred'%%29&sort=-created", service)
+	messages, err := listTyped[Message](db, "messages", query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query pending messages: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	var result struct {
-		Items []map[string]interface{} `json:"items"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return result.Items, nil
+	return messages, nil
 }
 
 // UpdateMessageStatus updates the status of a message
 func (db *DatabaseClient) UpdateMessageStatus(messageID, status string, metadata map[string]interface{}) error {
-	updateData := map[string]interface{}{
-		"status": status,
-	}
-
+	update := messageStatusUpdate{Status: status, Metadata: metadata}
 	if status == "delivered" {
-		updateData["delivered_at"] = time.Now().Format(time.RFC3339)
+		update.DeliveredAt = time.Now().Format(time.RFC3339)
 	}
 
-	if metadata != nil {
-		updateData["metadata"] = metadata
-	}
-
-	resp, err := db.makeRequest("PATCH", fmt.Sprintf("messages/records/%s", messageID), updateData)
-	if err != nil {
+	if err := updateTyped(db, "messages", messageID, update); err != nil {
 		return fmt.Errorf("failed to update message status: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
-	}
 
 	return nil
+}
+
+// messageStatusUpdate is the partial PATCH payload for UpdateMessageStatus.
+// It deliberately doesn't reuse Message, whose non-omitempty fields
+// (FromCallsign, Content, ...) would otherwise overwrite the rest of the
+// record with zero values on every status update.
+type messageStatusUpdate struct {
+	Status      string                 `json:"status"`
+	DeliveredAt string                 `json:"delivered_at,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 }
\ No newline at end of file
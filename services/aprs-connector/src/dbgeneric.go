@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// listResult is the PocketBase list envelope, generic over the record type.
+type listResult[T any] struct {
+	Items []T `json:"items"`
+}
+
+// listTyped queries a collection and decodes the result items as T.
+func listTyped[T any](db *DatabaseClient, collection, query string) ([]T, error) {
+	endpoint := fmt.Sprintf("%s/records", collection)
+	if query != "" {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, query)
+	}
+
+	resp, err := db.makeRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", collection, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result listResult[T]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode %s list response: %w", collection, err)
+	}
+
+	return result.Items, nil
+}
+
+// getTyped fetches a single record by ID and decodes it as T.
+func getTyped[T any](db *DatabaseClient, collection, id string) (T, error) {
+	var record T
+
+	resp, err := db.makeRequest("GET", fmt.Sprintf("%s/records/%s", collection, id), nil)
+	if err != nil {
+		return record, fmt.Errorf("failed to get %s/%s: %w", collection, id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return record, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
+		return record, fmt.Errorf("failed to decode %s record: %w", collection, err)
+	}
+
+	return record, nil
+}
+
+// createTyped POSTs record to collection and decodes PocketBase's response
+// (including the generated ID) back into a T.
+func createTyped[T any](db *DatabaseClient, collection string, record T) (T, error) {
+	var created T
+
+	resp, err := db.makeRequest("POST", fmt.Sprintf("%s/records", collection), record)
+	if err != nil {
+		return created, fmt.Errorf("failed to create %s record: %w", collection, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return created, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return created, fmt.Errorf("failed to decode created %s record: %w", collection, err)
+	}
+
+	return created, nil
+}
+
+// updateTyped PATCHes record id in collection with the given fields.
+func updateTyped[T any](db *DatabaseClient, collection, id string, record T) error {
+	resp, err := db.makeRequest("PATCH", fmt.Sprintf("%s/records/%s", collection, id), record)
+	if err != nil {
+		return fmt.Errorf("failed to update %s/%s: %w", collection, id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
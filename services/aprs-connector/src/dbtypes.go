@@ -0,0 +1,138 @@
+package main
+
+// Typed mirrors of the PocketBase collections this service reads and
+// writes. New call sites should prefer these plus the generic
+// list/get/create/update helpers in dbgeneric.go over hand-rolled
+// map[string]interface{} payloads.
+
+// MemberProfile mirrors a record in the member_profiles collection.
+type MemberProfile struct {
+	ID       string `json:"id"`
+	Callsign string `json:"callsign"`
+	User     string `json:"user"`
+}
+
+// User mirrors a record in the users collection.
+type User struct {
+	ID       string `json:"id"`
+	Approved bool   `json:"approved"`
+}
+
+// Message mirrors a record in the messages collection.
+type Message struct {
+	ID            string                 `json:"id"`
+	CorrelationID string                 `json:"correlation_id"`
+	FromCallsign  string                 `json:"from_callsign"`
+	FromService   string                 `json:"from_service"`
+	Routes        []string               `json:"routes"`
+	Content       string                 `json:"content"`
+	MessageType   string                 `json:"message_type"`
+	Status        string                 `json:"status"`
+	User          string                 `json:"user,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Conversation mirrors a record in the conversations collection.
+type Conversation struct {
+	ID               string   `json:"id"`
+	CorrelationID    string   `json:"correlation_id"`
+	ServicesInvolved []string `json:"services_involved"`
+	Subject          string   `json:"subject"`
+	Status           string   `json:"status"`
+	LastActivity     string   `json:"last_activity"`
+	MessageCount     int      `json:"message_count"`
+	InitiatedBy      string   `json:"initiated_by,omitempty"`
+}
+
+// SystemStatus mirrors a record in the system_status collection.
+type SystemStatus struct {
+	ID            string                 `json:"id"`
+	Service       string                 `json:"service"`
+	Status        string                 `json:"status"`
+	LastHeartbeat string                 `json:"last_heartbeat"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// APRSPacket mirrors a record in the aprs_packets collection.
+type APRSPacket struct {
+	ID              string `json:"id"`
+	RawPacket       string `json:"raw_packet"`
+	PacketType      string `json:"packet_type"`
+	FromCallsign    string `json:"from_callsign,omitempty"`
+	ToCallsign      string `json:"to_callsign,omitempty"`
+	Processed       bool   `json:"processed"`
+	ProcessingNotes string `json:"processing_notes,omitempty"`
+}
+
+// SystemLog mirrors a record in the system_logs collection.
+type SystemLog struct {
+	ID            string                 `json:"id"`
+	Level         string                 `json:"level"`
+	Service       string                 `json:"service"`
+	EventType     string                 `json:"event_type"`
+	Message       string                 `json:"message"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+}
+
+// PositionRecord mirrors a record in the aprs_positions collection.
+type PositionRecord struct {
+	ID           string  `json:"id"`
+	FromCallsign string  `json:"from_callsign"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	SymbolTable  string  `json:"symbol_table"`
+	SymbolCode   string  `json:"symbol_code"`
+	Comment      string  `json:"comment"`
+	Compressed   bool    `json:"compressed"`
+	MicE         bool    `json:"mic_e"`
+	RawPacket    string  `json:"raw_packet"`
+}
+
+// WeatherRecord mirrors a record in the aprs_weather collection.
+type WeatherRecord struct {
+	ID                string  `json:"id"`
+	FromCallsign      string  `json:"from_callsign"`
+	WindDirection     int     `json:"wind_direction"`
+	WindSpeedMPH      int     `json:"wind_speed_mph"`
+	GustMPH           int     `json:"gust_mph"`
+	TempF             int     `json:"temp_f"`
+	RainLastHour      float64 `json:"rain_last_hour"`
+	RainLast24h       float64 `json:"rain_last_24h"`
+	RainSinceMidnight float64 `json:"rain_since_midnight"`
+	Humidity          int     `json:"humidity"`
+	PressureTenths    int     `json:"pressure_tenths"`
+	RawPacket         string  `json:"raw_packet"`
+}
+
+// TelemetryRecord mirrors a record in the aprs_telemetry collection.
+type TelemetryRecord struct {
+	ID           string     `json:"id"`
+	FromCallsign string     `json:"from_callsign"`
+	Sequence     int        `json:"sequence"`
+	Analog       [5]float64 `json:"analog"`
+	Digital      string     `json:"digital"`
+	RawPacket    string     `json:"raw_packet"`
+}
+
+// ObjectRecord mirrors a record in the aprs_objects collection.
+type ObjectRecord struct {
+	ID           string  `json:"id"`
+	FromCallsign string  `json:"from_callsign"`
+	Name         string  `json:"name"`
+	Live         bool    `json:"live"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	SymbolTable  string  `json:"symbol_table"`
+	SymbolCode   string  `json:"symbol_code"`
+	Comment      string  `json:"comment"`
+	RawPacket    string  `json:"raw_packet"`
+}
+
+// StatusRecord mirrors a record in the aprs_status collection.
+type StatusRecord struct {
+	ID           string `json:"id"`
+	FromCallsign string `json:"from_callsign"`
+	Text         string `json:"text"`
+	RawPacket    string `json:"raw_packet"`
+}
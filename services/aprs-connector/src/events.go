@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// EventEnvelope is the JSON shape published on /events.
+type EventEnvelope struct {
+	Type      string      `json:"type"` // "packet", "message_routed", "status"
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// eventHub fans out EventEnvelopes to every connected /events subscriber.
+// Publishing never blocks on a slow reader: an event is dropped for a
+// subscriber whose buffer is full rather than stalling the rest of the
+// service; the subscriber itself stays subscribed.
+type eventHub struct {
+	mu          sync.Mutex
+	subscribers map[chan EventEnvelope]struct{}
+	logger      *logrus.Logger
+}
+
+func newEventHub(logger *logrus.Logger) *eventHub {
+	return &eventHub{
+		subscribers: make(map[chan EventEnvelope]struct{}),
+		logger:      logger,
+	}
+}
+
+// events is the process-wide hub every /events publish site uses. main()
+// replaces it with one built from the configured logger at startup.
+var events = newEventHub(logrus.New())
+
+func (h *eventHub) subscribe() chan EventEnvelope {
+	ch := make(chan EventEnvelope, 32)
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *eventHub) unsubscribe(ch chan EventEnvelope) {
+	h.mu.Lock()
+	delete(h.subscribers, ch)
+	h.mu.Unlock()
+	close(ch)
+}
+
+// publish delivers an event to every current subscriber.
+func (h *eventHub) publish(eventType string, data interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	envelope := EventEnvelope{Type: eventType, Timestamp: time.Now(), Data: data}
+	for ch := range h.subscribers {
+		select {
+		case ch <- envelope:
+		default:
+			h.logger.WithField("event_type", eventType).Warn("Dropping event for slow /events subscriber")
+		}
+	}
+}
+
+var eventsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Operator tooling connects from arbitrary hosts; auth is via bearer
+	// token rather than origin checking.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// eventsHandler authenticates and upgrades a WebSocket client, then streams
+// published events to it until it disconnects.
+func eventsHandler(hub *eventHub, token string, logger *logrus.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			auth := r.Header.Get("Authorization")
+			if auth != "Bearer "+token {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		conn, err := eventsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to upgrade /events connection")
+			return
+		}
+		defer conn.Close()
+
+		ch := hub.subscribe()
+		defer hub.unsubscribe(ch)
+
+		// Drain client-initiated frames (pings/closes) so the read side
+		// notices a disconnect promptly; subscribers don't send us data.
+		go func() {
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}()
+
+		for event := range ch {
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// startMetricsServer serves /metrics (Prometheus) and /events (WebSocket)
+// until ctx is cancelled.
+func startMetricsServer(ctx context.Context, cfg MetricsConfig, hub *eventHub, logger *logrus.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/events", eventsHandler(hub, cfg.EventsToken, logger))
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Port),
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logger.WithField("port", cfg.Port).Info("Starting metrics/events server")
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.WithError(err).Error("Metrics/events server error")
+	}
+}
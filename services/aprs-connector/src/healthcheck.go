@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// HealthCheck is a single named result within a HealthReport.
+type HealthCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// HealthReport is the result of RunHealthCheck: an overall verdict plus the
+// individual checks that produced it, suitable for publishing via
+// UpdateSystemStatus or returning to an external probe.
+type HealthReport struct {
+	Healthy   bool          `json:"healthy"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Checks    []HealthCheck `json:"checks"`
+}
+
+// FailingChecks returns the names of any checks that did not pass.
+func (r HealthReport) FailingChecks() []string {
+	var names []string
+	for _, c := range r.Checks {
+		if !c.Passed {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// RunHealthCheck runs the connector's posture checks: a live APRS-IS socket,
+// acceptable clock skew, a well-formed callsign/passcode, and reachable
+// storage. It never returns an error itself - failures are reported as
+// individual failed checks so callers can decide how to react (e.g. defer
+// sends rather than failing them outright).
+func (a *APRSClient) RunHealthCheck(ctx context.Context) (HealthReport, error) {
+	report := HealthReport{CheckedAt: time.Now(), Healthy: true}
+
+	addCheck := func(name string, passed bool, detail string) {
+		report.Checks = append(report.Checks, HealthCheck{Name: name, Passed: passed, Detail: detail})
+		if !passed {
+			report.Healthy = false
+		}
+	}
+
+	// (a) Socket liveness: have we heard from the server recently?
+	const heartbeatTolerance = 90 * time.Second
+	if lastActivity := a.LastActivity(); lastActivity.IsZero() {
+		addCheck("socket_activity", !a.IsConnected(), "no traffic received yet")
+	} else {
+		since := time.Since(lastActivity)
+		addCheck("socket_activity", since <= heartbeatTolerance,
+			fmt.Sprintf("last traffic %s ago", formatDuration(since)))
+	}
+
+	// (b) Clock skew: APRS timestamps are meaningless if our clock has drifted.
+	skew, err := a.checkClockSkew()
+	if err != nil {
+		addCheck("clock_skew", false, err.Error())
+	} else {
+		const skewTolerance = 5 * time.Second
+		addCheck("clock_skew", skew <= skewTolerance && skew >= -skewTolerance,
+			fmt.Sprintf("skew %s", formatDuration(skew)))
+	}
+
+	// (c) Callsign/passcode sanity.
+	addCheck("callsign_format", validateAPRSCallsign(a.Config().APRS.Callsign),
+		a.Config().APRS.Callsign)
+
+	passcodeOK := a.Config().APRS.ReadOnly || (a.Config().APRS.Passcode != "" && a.Config().APRS.Passcode != "-1")
+	addCheck("passcode_configured", passcodeOK, "read-only or non-default passcode required")
+
+	// (d) Database connectivity.
+	const dbLatencyTolerance = 2 * time.Second
+	latency, err := a.db.Ping()
+	if err != nil {
+		addCheck("database", false, err.Error())
+	} else {
+		addCheck("database", latency <= dbLatencyTolerance,
+			fmt.Sprintf("round trip %s", formatDuration(latency)))
+	}
+
+	return report, nil
+}
+
+// checkClockSkew approximates an NTP check by comparing the local clock
+// against the Date header returned by the database's HTTP server, which is
+// cheaper than standing up a real NTP client and good enough to catch a
+// badly drifted host clock.
+func (a *APRSClient) checkClockSkew() (time.Duration, error) {
+	serverTime, err := a.db.ServerTime()
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine reference time: %w", err)
+	}
+	return time.Since(serverTime), nil
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// IRCBridge relays messages between the configured IRC channel and the rest
+// of the connector. Callsigns are mapped to nicks by appending the
+// configured suffix (W1AW -> W1AW[APRS]) and back by stripping it.
+type IRCBridge struct {
+	config *Config
+	logger *logrus.Logger
+
+	connMu      sync.Mutex // guards conn/connected against concurrent writers/Stop
+	conn        net.Conn
+	connected   bool
+	stopChannel chan bool
+
+	incoming chan BridgeMessage
+}
+
+// NewIRCBridge creates a new IRC bridge from config.
+func NewIRCBridge(config *Config, logger *logrus.Logger) *IRCBridge {
+	return &IRCBridge{
+		config:      config,
+		logger:      logger,
+		stopChannel: make(chan bool),
+		incoming:    make(chan BridgeMessage, 16),
+	}
+}
+
+// Name identifies this bridge as a routing destination.
+func (i *IRCBridge) Name() string {
+	return "irc"
+}
+
+// Receive returns the channel messages relayed from IRC are published on.
+func (i *IRCBridge) Receive() <-chan BridgeMessage {
+	return i.incoming
+}
+
+// Start connects to the configured IRC server, joins the channel, and
+// begins relaying PRIVMSGs on the channel into Receive().
+func (i *IRCBridge) Start(ctx context.Context) error {
+	cfg := i.config.Services.IRC
+
+	timeout := 30 * time.Second
+	addr := fmt.Sprintf("%s:%d", cfg.Server, cfg.Port)
+
+	i.logger.WithFields(logrus.Fields{
+		"server":  cfg.Server,
+		"port":    cfg.Port,
+		"channel": cfg.Channel,
+	}).Info("Connecting to IRC")
+
+	var conn net.Conn
+	var err error
+	if cfg.TLS {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", addr, &tls.Config{ServerName: cfg.Server})
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to IRC: %w", err)
+	}
+
+	i.connMu.Lock()
+	i.conn = conn
+	i.connected = true
+	i.stopChannel = make(chan bool)
+	i.connMu.Unlock()
+
+	if err := i.writeLine(fmt.Sprintf("NICK %s", cfg.Nick)); err != nil {
+		return fmt.Errorf("failed to register IRC nick: %w", err)
+	}
+	if err := i.writeLine(fmt.Sprintf("USER %s 0 * :RARSMS APRS Bridge", cfg.Nick)); err != nil {
+		return fmt.Errorf("failed to register IRC user: %w", err)
+	}
+	if err := i.writeLine(fmt.Sprintf("JOIN %s", cfg.Channel)); err != nil {
+		return fmt.Errorf("failed to join IRC channel: %w", err)
+	}
+
+	go i.listen(ctx)
+
+	return nil
+}
+
+// Stop disconnects from IRC and closes the Receive channel.
+func (i *IRCBridge) Stop() error {
+	i.connMu.Lock()
+	defer i.connMu.Unlock()
+
+	if !i.connected {
+		return nil
+	}
+
+	if i.stopChannel != nil {
+		close(i.stopChannel)
+		i.stopChannel = nil
+	}
+	i.connected = false
+
+	err := i.conn.Close()
+	close(i.incoming)
+	return err
+}
+
+// Send relays a bridge message into the IRC channel as the sending
+// callsign's mapped nick.
+func (i *IRCBridge) Send(ctx context.Context, msg BridgeMessage) error {
+	line := fmt.Sprintf("PRIVMSG %s :<%s> %s", i.config.Services.IRC.Channel, i.callsignToNick(msg.FromCallsign), msg.Content)
+	return i.writeLine(line)
+}
+
+// callsignToNick maps an APRS callsign to its IRC nick, e.g. W1AW ->
+// W1AW[APRS].
+func (i *IRCBridge) callsignToNick(callsign string) string {
+	return callsign + i.config.Services.IRC.NickSuffix
+}
+
+// nickToCallsign reverses callsignToNick, stripping the configured suffix
+// if present.
+func (i *IRCBridge) nickToCallsign(nick string) string {
+	return strings.TrimSuffix(nick, i.config.Services.IRC.NickSuffix)
+}
+
+// writeLine writes a single CRLF-terminated line to the IRC socket,
+// holding connMu so Stop can't close the connection mid-write.
+func (i *IRCBridge) writeLine(line string) error {
+	i.connMu.Lock()
+	defer i.connMu.Unlock()
+
+	if !i.connected || i.conn == nil {
+		return fmt.Errorf("not connected to IRC")
+	}
+
+	_, err := i.conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+// listen reads lines from the IRC socket, answers PING with PONG, and
+// forwards channel PRIVMSGs onto incoming until stopped or disconnected.
+func (i *IRCBridge) listen(ctx context.Context) {
+	conn := i.conn
+	stopChannel := i.stopChannel
+	scanner := bufio.NewScanner(conn)
+
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stopChannel:
+			return
+		default:
+		}
+
+		line := strings.TrimRight(scanner.Text(), "\r\n")
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "PING") {
+			i.writeLine("PONG" + strings.TrimPrefix(line, "PING"))
+			continue
+		}
+
+		msg, ok := i.parsePrivmsg(line)
+		if !ok {
+			continue
+		}
+
+		select {
+		case i.incoming <- msg:
+		case <-ctx.Done():
+			return
+		case <-stopChannel:
+			return
+		}
+	}
+}
+
+// parsePrivmsg extracts a channel PRIVMSG as a BridgeMessage, mapping the
+// sending nick back to a callsign.
+func (i *IRCBridge) parsePrivmsg(line string) (BridgeMessage, bool) {
+	// :nick!user@host PRIVMSG #channel :text
+	if !strings.HasPrefix(line, ":") {
+		return BridgeMessage{}, false
+	}
+
+	rest := strings.TrimPrefix(line, ":")
+	prefix, rest, ok := strings.Cut(rest, " ")
+	if !ok {
+		return BridgeMessage{}, false
+	}
+	nick, _, _ := strings.Cut(prefix, "!")
+
+	command, rest, ok := strings.Cut(rest, " ")
+	if !ok || command != "PRIVMSG" {
+		return BridgeMessage{}, false
+	}
+
+	target, text, ok := strings.Cut(rest, " :")
+	if !ok || !strings.EqualFold(target, i.config.Services.IRC.Channel) {
+		return BridgeMessage{}, false
+	}
+
+	targetCallsign, content := extractTargetCallsign(text)
+
+	return BridgeMessage{
+		CorrelationID:  generateCorrelationID(),
+		FromCallsign:   i.nickToCallsign(nick),
+		FromService:    i.Name(),
+		Content:        content,
+		TargetCallsign: targetCallsign,
+	}, true
+}
+
+// targetCallsignRegex matches the "CALLSIGN: text" convention IRC users
+// address an APRS station with, e.g. "W1AW: on the air?".
+var targetCallsignRegex = regexp.MustCompile(`^([A-Za-z0-9]{1,9}(?:-[A-Za-z0-9]{1,2})?):\s*(.+)$`)
+
+// extractTargetCallsign splits text into an addressed callsign and the
+// remaining message content, per targetCallsignRegex. If text doesn't
+// match the convention, target is empty and content is text unchanged.
+func extractTargetCallsign(text string) (target, content string) {
+	if m := targetCallsignRegex.FindStringSubmatch(text); m != nil {
+		return strings.ToUpper(m[1]), m[2]
+	}
+	return "", text
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// knownSubsystems lists every subsystem name PackageLevels and GetLogger
+// accept. Keep in sync with the GetLogger calls in main.go.
+var knownSubsystems = map[string]bool{
+	"main":    true,
+	"aprs":    true,
+	"db":      true,
+	"irc":     true,
+	"bridge":  true,
+	"metrics": true,
+}
+
+// parsePackageLevels parses a "aprs=debug,db=info,irc=warn" string into a
+// per-subsystem level map, following the capnslog/dcrpool convention for
+// per-package verbosity overrides. Unknown subsystem names or level names
+// are rejected rather than silently ignored.
+func parsePackageLevels(raw string) (map[string]logrus.Level, error) {
+	levels := make(map[string]logrus.Level)
+	if raw == "" {
+		return levels, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid package_levels entry %q, expected subsystem=level", pair)
+		}
+
+		subsystem, levelName := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if !knownSubsystems[subsystem] {
+			return nil, fmt.Errorf("unknown subsystem %q in package_levels", subsystem)
+		}
+
+		level, err := logrus.ParseLevel(levelName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level %q for subsystem %q: %w", levelName, subsystem, err)
+		}
+		levels[subsystem] = level
+	}
+
+	return levels, nil
+}
+
+// loggerRegistry hands out one *logrus.Logger per subsystem, each sharing
+// the base logger's formatter/output/hooks but free to run at its own level
+// per Logging.PackageLevels.
+type loggerRegistry struct {
+	mu      sync.Mutex
+	base    *logrus.Logger
+	levels  map[string]logrus.Level
+	loggers map[string]*logrus.Logger
+}
+
+var registry *loggerRegistry
+
+// InitLogging prepares GetLogger to hand out per-subsystem loggers derived
+// from base. Must run once during startup, after base has been built by
+// setupLogger, and before any GetLogger call.
+func InitLogging(cfg LoggingConfig, base *logrus.Logger) error {
+	levels, err := parsePackageLevels(cfg.PackageLevels)
+	if err != nil {
+		return err
+	}
+
+	registry = &loggerRegistry{
+		base:    base,
+		levels:  levels,
+		loggers: make(map[string]*logrus.Logger),
+	}
+	return nil
+}
+
+// GetLogger returns the logger for subsystem, honoring any per-subsystem
+// level override from Logging.PackageLevels and otherwise inheriting the
+// base logger's level, formatter, output, and hooks. GetLogger panics if
+// called before InitLogging or with an unregistered subsystem name, since
+// both indicate a programming error rather than a runtime condition.
+func GetLogger(subsystem string) *logrus.Logger {
+	if registry == nil {
+		panic("logging: GetLogger called before InitLogging")
+	}
+	if !knownSubsystems[subsystem] {
+		panic(fmt.Sprintf("logging: unknown subsystem %q", subsystem))
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if logger, ok := registry.loggers[subsystem]; ok {
+		return logger
+	}
+
+	level := registry.base.Level
+	if override, ok := registry.levels[subsystem]; ok {
+		level = override
+	}
+
+	logger := newSubsystemLogger(registry.base, level)
+	registry.loggers[subsystem] = logger
+	return logger
+}
+
+// newSubsystemLogger builds a logger sharing base's formatter, output, and
+// hooks but with its own independent level, so raising one subsystem's
+// verbosity doesn't affect the others sharing the same sink.
+func newSubsystemLogger(base *logrus.Logger, level logrus.Level) *logrus.Logger {
+	logger := logrus.New()
+	logger.SetFormatter(base.Formatter)
+	logger.SetOutput(base.Out)
+	logger.ReplaceHooks(base.Hooks)
+	logger.SetReportCaller(base.ReportCaller)
+	logger.SetLevel(level)
+	return logger
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	lsyslog "github.com/sirupsen/logrus/hooks/syslog"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// buildLogWriter returns the io.Writer for the configured primary sink.
+// syslog and http sinks are wired up as logrus hooks instead, since they
+// don't behave like a plain writer, so this only handles stdout/stderr/file.
+func buildLogWriter(cfg LoggingConfig) (*os.File, *lumberjack.Logger) {
+	switch cfg.Sink {
+	case "file":
+		return nil, &lumberjack.Logger{
+			Filename:   cfg.File.Path,
+			MaxSize:    cfg.File.MaxSize,
+			MaxAge:     cfg.File.MaxAge,
+			MaxBackups: cfg.File.MaxBackups,
+			Compress:   cfg.File.Compress,
+		}
+	case "stderr":
+		return os.Stderr, nil
+	default:
+		return os.Stdout, nil
+	}
+}
+
+// attachSinkHooks wires up the syslog and/or HTTP sinks as logrus hooks, on
+// top of whatever the primary writer is, so multiple sinks can be combined
+// (e.g. file output plus an HTTP fan-out for warnings and above).
+func attachSinkHooks(logger *logrus.Logger, cfg LoggingConfig) {
+	switch cfg.Sink {
+	case "syslog":
+		hook, err := lsyslog.NewSyslogHook(cfg.Syslog.Network, cfg.Syslog.Address, syslog.LOG_INFO, cfg.Syslog.Tag)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to initialize syslog sink, falling back to stdout")
+		} else {
+			logger.AddHook(hook)
+			logger.SetOutput(ioDiscard{})
+		}
+	case "http":
+		logger.AddHook(newHTTPLogHook(cfg.HTTP, nil))
+	}
+
+	// Fan-out: route entries at or above the configured levels to the HTTP
+	// sink even when it isn't the primary sink.
+	if cfg.Sink != "http" && len(cfg.FanOutLevels) > 0 {
+		levels := make([]logrus.Level, 0, len(cfg.FanOutLevels))
+		for _, name := range cfg.FanOutLevels {
+			if level, err := logrus.ParseLevel(name); err == nil {
+				levels = append(levels, level)
+			}
+		}
+		if len(levels) > 0 {
+			logger.AddHook(newHTTPLogHook(cfg.HTTP, levels))
+		}
+	}
+}
+
+// ioDiscard is used in place of os.Stdout when a hook (e.g. syslog) is the
+// sole destination for log entries.
+type ioDiscard struct{}
+
+func (ioDiscard) Write(p []byte) (int, error) { return len(p), nil }
+
+// httpLogHook POSTs each log entry as JSON to a collector URL. Entries are
+// buffered on a channel and delivered by a background goroutine so a slow
+// or unreachable collector never blocks the caller; failed deliveries are
+// retried a bounded number of times before being dropped.
+type httpLogHook struct {
+	levels  []logrus.Level
+	client  *http.Client
+	url     string
+	queue   chan []byte
+	retries int
+}
+
+func newHTTPLogHook(cfg HTTPSinkConfig, levels []logrus.Level) *httpLogHook {
+	if levels == nil {
+		levels = logrus.AllLevels
+	}
+
+	bufferSize := cfg.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = 256
+	}
+	timeout := time.Duration(cfg.TimeoutSec) * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	hook := &httpLogHook{
+		levels:  levels,
+		client:  &http.Client{Timeout: timeout},
+		url:     cfg.URL,
+		queue:   make(chan []byte, bufferSize),
+		retries: cfg.MaxRetries,
+	}
+
+	go hook.run()
+	return hook
+}
+
+func (h *httpLogHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+func (h *httpLogHook) Fire(entry *logrus.Entry) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"level":   entry.Level.String(),
+		"message": entry.Message,
+		"time":    entry.Time.Format(time.RFC3339),
+		"fields":  entry.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case h.queue <- payload:
+	default:
+		// Buffer full; drop rather than block the logging caller.
+	}
+	return nil
+}
+
+// run delivers buffered entries, retrying transient failures with a short
+// backoff so a network blip doesn't drop log lines.
+func (h *httpLogHook) run() {
+	for payload := range h.queue {
+		var lastErr error
+		for attempt := 0; attempt <= h.retries; attempt++ {
+			resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(payload))
+			if err == nil {
+				resp.Body.Close()
+				if resp.StatusCode < 300 {
+					lastErr = nil
+					break
+				}
+				lastErr = fmt.Errorf("log sink returned status %d", resp.StatusCode)
+			} else {
+				lastErr = err
+			}
+			time.Sleep(time.Duration(attempt+1) * time.Second)
+		}
+		_ = lastErr // best-effort delivery; nothing left to report to on final failure
+	}
+}
@@ -2,10 +2,11 @@ package main
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -16,21 +17,42 @@ const (
 	Version = "1.0.0"
 )
 
+// aprsMessageIDCounter hands out per-process-unique APRS message IDs.
+// Sends happen concurrently across the worker pool (see RunMessageSenderPool),
+// so a timestamp-derived ID isn't enough to avoid two sends to the same
+// target callsign landing on the same ackKey in the same second.
+var aprsMessageIDCounter uint64
+
+// nextAPRSMessageID returns the next message ID in the sequence, wrapped to
+// fit the ~5-character APRS message ID convention.
+func nextAPRSMessageID() string {
+	n := atomic.AddUint64(&aprsMessageIDCounter, 1)
+	return fmt.Sprintf("%d", n%100000)
+}
+
 func main() {
-	// Parse command line flags
-	var (
-		configPath = flag.String("config", "/app/config/config.yaml", "Path to configuration file")
-		version    = flag.Bool("version", false, "Show version information")
-	)
-	flag.Parse()
-
-	if *version {
+	// Parse command line flags. The flag set mirrors the config file and
+	// environment variables so any key can be pinned from the CLI,
+	// discoverable via --help; see BuildFlagSet/applyFlagOverrides.
+	flags := BuildFlagSet(os.Args[0])
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		fmt.Printf("Failed to parse flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	version, _ := flags.GetBool("version")
+	if version {
 		fmt.Printf("RARSMS APRS Connector v%s\n", Version)
 		os.Exit(0)
 	}
 
+	configPath, _ := flags.GetString("config")
+	configDir, _ := flags.GetString("config-dir")
+	configOverlay, _ := flags.GetString("config-overlay")
+	migrateConfigFile, _ := flags.GetBool("migrate-config")
+
 	// Load configuration
-	config, err := LoadConfig(*configPath)
+	config, err := LoadConfig(configPath, configDir, configOverlay, flags, migrateConfigFile)
 	if err != nil {
 		fmt.Printf("Failed to load configuration: %v\n", err)
 		os.Exit(1)
@@ -38,9 +60,17 @@ func main() {
 
 	// Setup logger
 	logger := setupLogger(config)
+	if err := InitLogging(config.Logging, logger); err != nil {
+		fmt.Printf("Failed to initialize logging: %v\n", err)
+		os.Exit(1)
+	}
+	events = newEventHub(logger)
+	if config.APRS.PasscodeDerived {
+		logger.WithField("callsign", config.APRS.Callsign).Debug("Derived APRS passcode from callsign")
+	}
 	logger.WithFields(logrus.Fields{
 		"version":    Version,
-		"config":     *configPath,
+		"config":     configPath,
 		"callsign":   config.APRS.Callsign,
 		"server":     config.APRS.Server,
 	}).Info("Starting RARSMS APRS Connector")
@@ -51,8 +81,15 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Wrap config so it can be hot-reloaded on SIGHUP
+	configHolder := NewConfigHolder(configPath, configDir, configOverlay, config, logger)
+	configHolder.WatchSIGHUP()
+
 	// Create database client
-	db := NewDatabaseClient(config, logger)
+	db := NewDatabaseClient(config, GetLogger("db"))
+	if err := db.Authenticate(); err != nil {
+		logger.WithError(err).Warn("Failed to authenticate with database, continuing unauthenticated")
+	}
 
 	// Initialize system status
 	if err := db.UpdateSystemStatus("aprs-connector", "starting", getSystemInfo()); err != nil {
@@ -60,12 +97,31 @@ func main() {
 	}
 
 	// Create APRS client
-	aprsClient := NewAPRSClient(config, logger, db)
+	aprsClient := NewAPRSClient(config, GetLogger("aprs"), db)
+
+	// Reloading swaps the config pointer each consumer reads through;
+	// in-flight connections keep running under the old settings until
+	// their next reconnect.
+	configHolder.OnReload(func(newConfig *Config) {
+		aprsClient.SetConfig(newConfig)
+		db.SetConfig(newConfig)
+	})
+
+	// Create the IRC bridge, if configured
+	var ircBridge *IRCBridge
+	if config.Services.IRC.Enabled {
+		ircBridge = NewIRCBridge(config, GetLogger("irc"))
+	}
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Start the metrics/events HTTP server
+	if config.Services.Metrics.Enabled {
+		go startMetricsServer(ctx, config.Services.Metrics, events, GetLogger("metrics"))
+	}
+
 	// Setup signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -85,6 +141,16 @@ func main() {
 		}
 	}()
 
+	// Start the IRC bridge, if configured
+	if ircBridge != nil {
+		bridgeLogger := GetLogger("bridge")
+		go func() {
+			if err := runBridge(ctx, ircBridge, db, bridgeLogger); err != nil {
+				bridgeLogger.WithError(err).Error("IRC bridge error")
+			}
+		}()
+	}
+
 	// Wait for shutdown signal
 	select {
 	case sig := <-sigChan:
@@ -101,6 +167,12 @@ func main() {
 		logger.WithError(err).Warn("Error during APRS disconnect")
 	}
 
+	if ircBridge != nil {
+		if err := ircBridge.Stop(); err != nil {
+			logger.WithError(err).Warn("Error during IRC disconnect")
+		}
+	}
+
 	// Update system status
 	if err := db.UpdateSystemStatus("aprs-connector", "offline", map[string]interface{}{
 		"shutdown_reason": "graceful",
@@ -112,8 +184,29 @@ func main() {
 	logger.Info("APRS Connector stopped")
 }
 
-// runService runs the main APRS service with reconnection logic
+// runService runs the main APRS service with pool-aware reconnection logic.
+// Reconnect timing follows the configured retry policy (Fibonacci by
+// default: 1s, 1s, 2s, 3s, 5s, 8s, ...), capped at the configured max and
+// jittered, resetting back to the start on every successful connect so a
+// single flapping pool entry doesn't escalate delay for the whole service.
 func runService(ctx context.Context, aprsClient *APRSClient, logger *logrus.Logger) error {
+	policy := aprsClient.Config().Services.APRSConnector.Retry.ToPolicy()
+	if policy.MaxDelay <= 0 {
+		if maxBackoff := time.Duration(aprsClient.Config().APRS.EndpointPool.MaxBackoff) * time.Second; maxBackoff > 0 {
+			policy.MaxDelay = maxBackoff
+		} else {
+			policy.MaxDelay = 60 * time.Second
+		}
+	}
+	nextDelay := newBackoffSequence(policy)
+	attempt := 0
+	episodeStart := time.Now()
+	resetDelay := func() {
+		nextDelay = newBackoffSequence(policy)
+		attempt = 0
+		episodeStart = time.Now()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -121,31 +214,43 @@ func runService(ctx context.Context, aprsClient *APRSClient, logger *logrus.Logg
 		default:
 		}
 
-		// Connect to APRS-IS
-		err := retryWithBackoff(func() error {
-			return aprsClient.Connect()
-		}, 5, 5*time.Second, logger)
+		// Connect to the next endpoint offered by the pool
+		metrics.reconnects.Inc()
+		err := aprsClient.Connect()
 
 		if err != nil {
-			logger.WithError(err).Error("Failed to connect to APRS-IS after retries")
+			metrics.connected.Set(0)
+			logger.WithError(err).WithField("endpoint", aprsClient.current).
+				Error("Failed to connect to APRS-IS")
 
 			// Update system status to error
 			if dbErr := aprsClient.db.UpdateSystemStatus("aprs-connector", "error", map[string]interface{}{
 				"error":      err.Error(),
 				"error_time": time.Now().Unix(),
+				"endpoints":  aprsClient.pool.Status(),
 			}); dbErr != nil {
 				logger.WithError(dbErr).Warn("Failed to update error status")
 			}
 
+			attempt++
+			delay := nextDelay()
+			if giveUpErr := checkRetryBounds(policy, attempt, time.Since(episodeStart), delay); giveUpErr != nil {
+				return giveUpErr
+			}
+			logger.WithField("delay", delay).Info("Waiting before trying next APRS-IS endpoint")
+
 			// Wait before trying again
 			select {
 			case <-ctx.Done():
 				return nil
-			case <-time.After(time.Duration(aprsClient.config.Services.APRSConnector.ReconnectDelay) * time.Second):
+			case <-time.After(delay):
 				continue
 			}
 		}
 
+		resetDelay()
+		metrics.connected.Set(1)
+
 		// Start heartbeat
 		aprsClient.StartHeartbeat()
 
@@ -165,140 +270,386 @@ func runService(ctx context.Context, aprsClient *APRSClient, logger *logrus.Logg
 		}
 
 		// Disconnect and wait before reconnecting
+		metrics.connected.Set(0)
 		aprsClient.Disconnect()
 
 		if ctx.Err() != nil {
 			return nil
 		}
 
-		logger.WithField("delay", aprsClient.config.Services.APRSConnector.ReconnectDelay).
-			Info("Waiting before reconnecting to APRS-IS")
+		attempt++
+		delay := nextDelay()
+		if giveUpErr := checkRetryBounds(policy, attempt, time.Since(episodeStart), delay); giveUpErr != nil {
+			return giveUpErr
+		}
+		logger.WithField("delay", delay).Info("Waiting before reconnecting to APRS-IS")
 
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-time.After(time.Duration(aprsClient.config.Services.APRSConnector.ReconnectDelay) * time.Second):
+		case <-time.After(delay):
 			logger.Info("Attempting to reconnect to APRS-IS")
 		}
 	}
 }
 
-// runMessageSender handles sending messages from database to APRS
+// checkRetryBounds reports whether policy's MaxRetries or TotalTimeout have
+// been exceeded after attempt failed connection attempts spanning elapsed,
+// about to sleep for delay before the next one. A zero MaxRetries or
+// TotalTimeout means that bound is unenforced.
+func checkRetryBounds(policy RetryPolicy, attempt int, elapsed, delay time.Duration) error {
+	if policy.MaxRetries > 0 && attempt >= policy.MaxRetries {
+		return fmt.Errorf("giving up after %d failed connection attempts (retry.max_retries)", attempt)
+	}
+	if policy.TotalTimeout > 0 && elapsed+delay > policy.TotalTimeout {
+		return fmt.Errorf("giving up after %s without a successful connection (retry.total_timeout_seconds)", elapsed.Round(time.Second))
+	}
+	return nil
+}
+
+// runMessageSender sweeps pending messages onto a channel on a fixed
+// interval and drains it with a pool of workers, each throttled by a
+// global and per-destination-callsign rate limiter so bursts of outbound
+// traffic don't stall behind one slow send or flood APRS-IS.
 func runMessageSender(ctx context.Context, aprsClient *APRSClient, db *DatabaseClient, logger *logrus.Logger) error {
-	ticker := time.NewTicker(10 * time.Second) // Check every 10 seconds
+	cfg := aprsClient.Config().Services.APRSConnector
+
+	sweepInterval := time.Duration(cfg.SweepInterval) * time.Second
+	if sweepInterval <= 0 {
+		sweepInterval = 10 * time.Second
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	limiter := newRateLimiter(cfg.GlobalRate, cfg.PerCallsignRate)
+	queue := make(chan Message, workers*4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msgData, ok := <-queue:
+					if !ok {
+						return
+					}
+					sendPendingMessage(aprsClient, db, logger, limiter, msgData)
+				}
+			}
+		}(i)
+	}
+
+	ticker := time.NewTicker(sweepInterval)
 	defer ticker.Stop()
 
+sweepLoop:
 	for {
 		select {
 		case <-ctx.Done():
-			return nil
+			break sweepLoop
 		case <-ticker.C:
 			if !aprsClient.IsConnected() {
 				continue
 			}
 
-			// Get pending messages for APRS
+			sweepAckRetries(aprsClient, db, logger, limiter)
+			metrics.authTokenTTL.Set(db.TokenTTLRemaining().Seconds())
+
 			messages, err := db.GetPendingMessages()
 			if err != nil {
 				logger.WithError(err).Warn("Failed to get pending messages")
 				continue
 			}
+			if len(messages) == 0 {
+				continue
+			}
 
-			for _, msgData := range messages {
-				select {
-				case <-ctx.Done():
-					return nil
-				default:
+			// Preflight: don't burn send attempts if the connector is in a
+			// degraded state. Deferred messages are retried on the next sweep.
+			report, _ := aprsClient.RunHealthCheck(ctx)
+			if !report.Healthy {
+				logger.WithField("failing_checks", report.FailingChecks()).
+					Warn("Preflight check failed, deferring pending messages")
+
+				if err := db.UpdateSystemStatus("aprs-connector", "degraded", map[string]interface{}{
+					"failing_checks": report.FailingChecks(),
+					"checked_at":     report.CheckedAt.Unix(),
+				}); err != nil {
+					logger.WithError(err).Warn("Failed to publish degraded status")
 				}
 
-				messageID, _ := msgData["id"].(string)
-				fromCallsign, _ := msgData["from_callsign"].(string)
-				content, _ := msgData["content"].(string)
-				correlationID, _ := msgData["correlation_id"].(string)
-
-				// Extract target callsign from metadata or use from_callsign
-				metadata, _ := msgData["metadata"].(map[string]interface{})
-				var targetCallsign string
-				if metadata != nil {
-					if target, ok := metadata["target_callsign"].(string); ok {
-						targetCallsign = target
+				for _, msgData := range messages {
+					if err := db.UpdateMessageStatus(msgData.ID, "deferred", map[string]interface{}{
+						"reason": report.FailingChecks(),
+					}); err != nil {
+						logger.WithError(err).Warn("Failed to defer message")
 					}
 				}
-				if targetCallsign == "" {
-					targetCallsign = fromCallsign
+				continue
+			}
+
+			for _, msgData := range messages {
+				select {
+				case <-ctx.Done():
+					break sweepLoop
+				case queue <- msgData:
 				}
+			}
+		}
+	}
 
-				// Validate content
-				if !isValidMessageContent(content) {
-					logger.WithFields(logrus.Fields{
-						"message_id":     messageID,
-						"correlation_id": correlationID,
-					}).Warn("Invalid message content, marking as failed")
+	// Drain gracefully: stop accepting new work and let in-flight sends
+	// finish; anything still queued is left pending and picked up by the
+	// next sweep after a restart.
+	close(queue)
+	wg.Wait()
+	return nil
+}
 
-					if err := db.UpdateMessageStatus(messageID, "failed", map[string]interface{}{
-						"error": "invalid content",
-					}); err != nil {
-						logger.WithError(err).Warn("Failed to update message status")
-					}
-					continue
-				}
+// sweepAckRetries retransmits outbound messages still awaiting an ACK per
+// ackRetrySchedule, and marks those that have exhausted it as failed.
+func sweepAckRetries(aprsClient *APRSClient, db *DatabaseClient, logger *logrus.Logger, limiter *rateLimiter) {
+	retry, expired := aprsClient.acks.DueForRetry()
+	metrics.pendingAcks.Set(float64(aprsClient.acks.Len()))
+
+	for _, p := range retry {
+		limiter.Wait(p.toCallsign)
+		// Use the untracked send: this entry is already in acks (DueForRetry
+		// already bumped its attempt count), so re-tracking here would reset it.
+		if err := aprsClient.SendMessage(p.toCallsign, p.content, p.messageID); err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"target":     p.toCallsign,
+				"message_id": p.messageID,
+				"attempt":    p.attempts,
+			}).Warn("Failed to retransmit unacked message")
+			continue
+		}
+		logger.WithFields(logrus.Fields{
+			"target":     p.toCallsign,
+			"message_id": p.messageID,
+			"attempt":    p.attempts,
+		}).Info("Retransmitted unacked APRS message")
+	}
 
-				// Sanitize and truncate content for APRS
-				sanitizedContent := sanitizeMessageContent(content)
-				truncatedContent := truncateForAPRS(sanitizedContent, 67)
-
-				// Generate message ID for APRS
-				aprsMessageID := fmt.Sprintf("%d", time.Now().Unix()%10000)
-
-				// Send message via APRS
-				if err := aprsClient.SendMessage(targetCallsign, truncatedContent, aprsMessageID); err != nil {
-					logger.WithError(err).WithFields(logrus.Fields{
-						"message_id":     messageID,
-						"target":         targetCallsign,
-						"correlation_id": correlationID,
-					}).Error("Failed to send APRS message")
-
-					// Update status to failed
-					if err := db.UpdateMessageStatus(messageID, "failed", map[string]interface{}{
-						"error":            err.Error(),
-						"aprs_message_id":  aprsMessageID,
-						"truncated_content": truncatedContent,
-					}); err != nil {
-						logger.WithError(err).Warn("Failed to update message status")
-					}
-				} else {
-					logger.WithFields(logrus.Fields{
-						"message_id":      messageID,
-						"target":          targetCallsign,
-						"correlation_id":  correlationID,
-						"aprs_message_id": aprsMessageID,
-					}).Info("Successfully sent APRS message")
-
-					// Update status to delivered
-					if err := db.UpdateMessageStatus(messageID, "delivered", map[string]interface{}{
-						"aprs_message_id":   aprsMessageID,
-						"truncated_content": truncatedContent,
-						"delivery_method":   "aprs-is",
-					}); err != nil {
-						logger.WithError(err).Warn("Failed to update message status")
-					}
+	for _, p := range expired {
+		logger.WithFields(logrus.Fields{
+			"target":     p.toCallsign,
+			"message_id": p.messageID,
+		}).Warn("Message exhausted ACK retries, marking failed")
 
-					// Log successful delivery
-					if err := db.LogEvent("info", "aprs", "message",
-						fmt.Sprintf("Message delivered to %s via APRS", targetCallsign),
-						map[string]interface{}{
-							"message_id":      messageID,
-							"target_callsign": targetCallsign,
-							"aprs_message_id": aprsMessageID,
-							"content_length":  len(truncatedContent),
-						}, correlationID); err != nil {
-						logger.WithError(err).Warn("Failed to log delivery event")
-					}
-				}
+		if p.dbMessageID == "" {
+			continue
+		}
+		if err := db.UpdateMessageStatus(p.dbMessageID, "failed", map[string]interface{}{
+			"error":        "no ACK received after retries",
+			"ack_attempts": p.attempts,
+		}); err != nil {
+			logger.WithError(err).Warn("Failed to mark unacked message failed")
+		}
+	}
+}
+
+// sendPendingMessage validates, rate-limits, and delivers a single pending
+// message, updating its status in the database based on the outcome.
+func sendPendingMessage(aprsClient *APRSClient, db *DatabaseClient, logger *logrus.Logger, limiter *rateLimiter, msgData Message) {
+	messageID := msgData.ID
+	fromCallsign := msgData.FromCallsign
+	content := msgData.Content
+	correlationID := msgData.CorrelationID
+
+	// Extract target callsign from metadata or use from_callsign
+	var targetCallsign string
+	if target, ok := msgData.Metadata["target_callsign"].(string); ok {
+		targetCallsign = target
+	}
+	if targetCallsign == "" {
+		targetCallsign = fromCallsign
+	}
+
+	// Validate content
+	if !isValidMessageContent(content) {
+		logger.WithFields(logrus.Fields{
+			"message_id":     messageID,
+			"correlation_id": correlationID,
+		}).Warn("Invalid message content, marking as failed")
+
+		if err := db.UpdateMessageStatus(messageID, "failed", map[string]interface{}{
+			"error": "invalid content",
+		}); err != nil {
+			logger.WithError(err).Warn("Failed to update message status")
+		}
+		return
+	}
+
+	// Sanitize and truncate content for APRS
+	sanitizedContent := sanitizeMessageContent(content)
+	truncatedContent := truncateForAPRS(sanitizedContent, 67)
+
+	// Generate message ID for APRS
+	aprsMessageID := nextAPRSMessageID()
 
-				// Small delay between messages to avoid flooding
-				time.Sleep(2 * time.Second)
+	// Respect the global and per-destination-callsign send rate before
+	// touching the socket; a path rule's rate_limit_per_sec overrides the
+	// default for its matching callsigns.
+	pathRate := 0.0
+	if pathCfg, ok := aprsClient.Config().PathFor(targetCallsign); ok {
+		pathRate = pathCfg.RateLimitPerSec
+	}
+	limiter.WaitAt(targetCallsign, pathRate)
+
+	// Send message via APRS, tracking it for ACK retry
+	if err := aprsClient.SendTrackedMessage(targetCallsign, truncatedContent, aprsMessageID, messageID); err != nil {
+		logger.WithError(err).WithFields(logrus.Fields{
+			"message_id":     messageID,
+			"target":         targetCallsign,
+			"correlation_id": correlationID,
+		}).Error("Failed to send APRS message")
+
+		// Update status to failed
+		if err := db.UpdateMessageStatus(messageID, "failed", map[string]interface{}{
+			"error":             err.Error(),
+			"aprs_message_id":   aprsMessageID,
+			"truncated_content": truncatedContent,
+		}); err != nil {
+			logger.WithError(err).Warn("Failed to update message status")
+		}
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"message_id":      messageID,
+		"target":          targetCallsign,
+		"correlation_id":  correlationID,
+		"aprs_message_id": aprsMessageID,
+	}).Info("Successfully sent APRS message")
+
+	// Status becomes "delivered" once a matching ACK arrives (see
+	// handleIncomingAck) or "failed" once retries are exhausted (see
+	// sweepAckRetries); until then it's in flight.
+	if err := db.UpdateMessageStatus(messageID, "sent", map[string]interface{}{
+		"aprs_message_id":   aprsMessageID,
+		"truncated_content": truncatedContent,
+	}); err != nil {
+		logger.WithError(err).Warn("Failed to update message status")
+	}
+
+	// Log successful delivery
+	if err := db.LogEvent("info", "aprs", "message",
+		fmt.Sprintf("Message delivered to %s via APRS", targetCallsign),
+		map[string]interface{}{
+			"message_id":      messageID,
+			"target_callsign": targetCallsign,
+			"aprs_message_id": aprsMessageID,
+			"content_length":  len(truncatedContent),
+		}, correlationID); err != nil {
+		logger.WithError(err).Warn("Failed to log delivery event")
+	}
+}
+
+// runBridge connects a Bridge, relays messages it receives into the
+// database with routing applied, and sweeps messages routed to it for
+// outbound delivery. It is the generic counterpart to runService/
+// runMessageSender for bridges that don't need APRS-specific framing.
+func runBridge(ctx context.Context, bridge Bridge, db *DatabaseClient, logger *logrus.Logger) error {
+	if err := bridge.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start %s bridge: %w", bridge.Name(), err)
+	}
+
+	go relayBridgeIncoming(ctx, bridge, db, logger)
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			sweepBridgeOutbound(ctx, bridge, db, logger)
+		}
+	}
+}
+
+// relayBridgeIncoming stores every message the bridge receives, tagged
+// with the routes configured for that bridge as a source.
+func relayBridgeIncoming(ctx context.Context, bridge Bridge, db *DatabaseClient, logger *logrus.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-bridge.Receive():
+			if !ok {
+				return
+			}
+
+			messageData := Message{
+				CorrelationID: msg.CorrelationID,
+				FromCallsign:  msg.FromCallsign,
+				FromService:   bridge.Name(),
+				Routes:        db.Config().Services.Routing.DestinationsFor(bridge.Name()),
+				Content:       msg.Content,
+				MessageType:   "message",
+				Status:        "pending",
+			}
+			if msg.TargetCallsign != "" {
+				messageData.Metadata = map[string]interface{}{"target_callsign": msg.TargetCallsign}
+			}
+
+			if err := db.CreateMessage(messageData); err != nil {
+				logger.WithError(err).WithField("bridge", bridge.Name()).Warn("Failed to store bridged message")
+				continue
 			}
+
+			for _, dest := range db.Config().Services.Routing.DestinationsFor(bridge.Name()) {
+				metrics.messagesRouted.WithLabelValues(bridge.Name(), dest).Inc()
+			}
+			events.publish("message_routed", messageData)
+		}
+	}
+}
+
+// sweepBridgeOutbound delivers messages routed to bridge and marks them
+// delivered or failed based on the outcome.
+func sweepBridgeOutbound(ctx context.Context, bridge Bridge, db *DatabaseClient, logger *logrus.Logger) {
+	messages, err := db.GetPendingMessagesFor(bridge.Name())
+	if err != nil {
+		logger.WithError(err).WithField("bridge", bridge.Name()).Warn("Failed to get pending messages")
+		return
+	}
+
+	for _, msgData := range messages {
+		messageID := msgData.ID
+
+		msg := BridgeMessage{
+			CorrelationID: msgData.CorrelationID,
+			FromCallsign:  msgData.FromCallsign,
+			Content:       msgData.Content,
+		}
+
+		if err := bridge.Send(ctx, msg); err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"message_id": messageID,
+				"bridge":     bridge.Name(),
+			}).Error("Failed to deliver bridged message")
+
+			if err := db.UpdateMessageStatus(messageID, "failed", map[string]interface{}{
+				"error": err.Error(),
+			}); err != nil {
+				logger.WithError(err).Warn("Failed to update message status")
+			}
+			continue
+		}
+
+		if err := db.UpdateMessageStatus(messageID, "delivered", map[string]interface{}{
+			"delivery_method": bridge.Name(),
+		}); err != nil {
+			logger.WithError(err).Warn("Failed to update message status")
 		}
 	}
 }
\ No newline at end of file
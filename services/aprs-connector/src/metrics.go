@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// metrics holds every Prometheus collector the connector exports. It's a
+// package-level var (rather than threaded through every struct) because the
+// default registerer is itself a global and these are created exactly once
+// at process start.
+var metrics = struct {
+	packetsReceived *prometheus.CounterVec
+	messagesRouted  *prometheus.CounterVec
+	acksSent        prometheus.Counter
+	acksReceived    prometheus.Counter
+	authDenials     prometheus.Counter
+	dbCallLatency   *prometheus.HistogramVec
+	reconnects      prometheus.Counter
+
+	connected    prometheus.Gauge
+	pendingAcks  prometheus.Gauge
+	authTokenTTL prometheus.Gauge
+}{
+	packetsReceived: promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rarsms_aprs_packets_received_total",
+		Help: "APRS packets received from APRS-IS, by packet type.",
+	}, []string{"packet_type"}),
+
+	messagesRouted: promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rarsms_messages_routed_total",
+		Help: "Messages routed between bridges, by source and destination.",
+	}, []string{"from_service", "to_service"}),
+
+	acksSent: promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rarsms_aprs_acks_sent_total",
+		Help: "ACKs sent in response to incoming APRS messages.",
+	}),
+
+	acksReceived: promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rarsms_aprs_acks_received_total",
+		Help: "ACKs received for outbound APRS messages.",
+	}),
+
+	authDenials: promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rarsms_aprs_auth_denials_total",
+		Help: "Messages rejected because the sending callsign is not an authorized member.",
+	}),
+
+	dbCallLatency: promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rarsms_db_call_duration_seconds",
+		Help:    "PocketBase request latency, by HTTP method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"}),
+
+	reconnects: promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rarsms_aprs_reconnects_total",
+		Help: "APRS-IS reconnection attempts.",
+	}),
+
+	connected: promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rarsms_aprs_connected",
+		Help: "1 if currently connected to APRS-IS, 0 otherwise.",
+	}),
+
+	pendingAcks: promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rarsms_aprs_pending_acks",
+		Help: "Outbound messages awaiting an ACK.",
+	}),
+
+	authTokenTTL: promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rarsms_db_auth_token_ttl_seconds",
+		Help: "Estimated seconds remaining before the cached PocketBase auth token expires.",
+	}),
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// APRSEndpoint represents a single APRS-IS server the connector can use.
+type APRSEndpoint struct {
+	Host     string        `yaml:"host"`
+	Port     int           `yaml:"port"`
+	Passcode string        `yaml:"passcode"`
+	Priority int           `yaml:"priority"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// Addr returns the host:port form used to dial the endpoint.
+func (e APRSEndpoint) Addr() string {
+	return fmt.Sprintf("%s:%d", e.Host, e.Port)
+}
+
+// endpointHealth tracks the failover state of a single pool entry.
+type endpointHealth struct {
+	healthy     bool
+	failures    int
+	cooldownTil time.Time
+}
+
+// EndpointPool selects the next APRS-IS endpoint to try, taking each
+// endpoint's priority and recent health into account. Endpoints that fail
+// are marked unhealthy for a cooldown period before they are offered again.
+type EndpointPool struct {
+	mu        sync.Mutex
+	endpoints []APRSEndpoint
+	health    map[string]*endpointHealth
+	cooldown  time.Duration
+	lastIdx   int
+}
+
+// NewEndpointPool builds a pool from a priority-ordered list of endpoints.
+// Lower Priority values are preferred; ties are broken round-robin.
+func NewEndpointPool(endpoints []APRSEndpoint, cooldown time.Duration) *EndpointPool {
+	health := make(map[string]*endpointHealth, len(endpoints))
+	for _, e := range endpoints {
+		health[e.Addr()] = &endpointHealth{healthy: true}
+	}
+
+	return &EndpointPool{
+		endpoints: endpoints,
+		health:    health,
+		cooldown:  cooldown,
+		lastIdx:   -1,
+	}
+}
+
+// Next returns the lowest-priority endpoint that is either healthy or past
+// its cooldown (the two are treated alike), breaking ties round-robin. If
+// every endpoint is still in cooldown it falls back to plain round-robin. It
+// returns nil if the pool has no endpoints at all.
+func (p *EndpointPool) Next() *APRSEndpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.endpoints) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	best := -1
+	for offset := 1; offset <= len(p.endpoints); offset++ {
+		idx := (p.lastIdx + offset) % len(p.endpoints)
+		h := p.health[p.endpoints[idx].Addr()]
+		if h.healthy || now.After(h.cooldownTil) {
+			if best == -1 || p.endpoints[idx].Priority < p.endpoints[best].Priority {
+				best = idx
+			}
+		}
+	}
+
+	if best == -1 {
+		// Everything is in cooldown; fall back to round-robin so we keep trying.
+		best = (p.lastIdx + 1) % len(p.endpoints)
+	}
+
+	p.lastIdx = best
+	endpoint := p.endpoints[best]
+	return &endpoint
+}
+
+// MarkFailed marks an endpoint unhealthy for the configured cooldown period.
+func (p *EndpointPool) MarkFailed(endpoint APRSEndpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h, ok := p.health[endpoint.Addr()]
+	if !ok {
+		h = &endpointHealth{}
+		p.health[endpoint.Addr()] = h
+	}
+
+	h.healthy = false
+	h.failures++
+	h.cooldownTil = time.Now().Add(p.cooldown)
+}
+
+// MarkHealthy clears an endpoint's failure state after a successful connect.
+func (p *EndpointPool) MarkHealthy(endpoint APRSEndpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h, ok := p.health[endpoint.Addr()]
+	if !ok {
+		h = &endpointHealth{}
+		p.health[endpoint.Addr()] = h
+	}
+
+	h.healthy = true
+	h.failures = 0
+}
+
+// Status returns a snapshot of every endpoint's health, suitable for
+// reporting via UpdateSystemStatus.
+func (p *EndpointPool) Status() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status := make(map[string]interface{}, len(p.endpoints))
+	for _, e := range p.endpoints {
+		h := p.health[e.Addr()]
+		status[e.Addr()] = map[string]interface{}{
+			"healthy":  h.healthy,
+			"failures": h.failures,
+			"priority": e.Priority,
+		}
+	}
+	return status
+}
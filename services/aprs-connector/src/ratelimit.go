@@ -0,0 +1,111 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket is a simple token-bucket rate limiter refilled continuously
+// at ratePerSec, holding at most burst tokens.
+type tokenBucket struct {
+	mu         sync.Mutex
+	ratePerSec float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(ratePerSec float64) *tokenBucket {
+	if ratePerSec <= 0 {
+		ratePerSec = 1
+	}
+	burst := ratePerSec
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		ratePerSec: ratePerSec,
+		burst:      burst,
+		tokens:     burst,
+		lastRefill: time.Now(),
+	}
+}
+
+// wait blocks until a token is available, sleeping for the delay reserve
+// reports. It cannot be cancelled; callers that need cancellation can call
+// reserve directly and select on a timer built from its delay.
+func (b *tokenBucket) wait() {
+	for {
+		d := b.reserve()
+		if d <= 0 {
+			return
+		}
+		time.Sleep(d)
+	}
+}
+
+// reserve returns how long the caller must wait before a token is available,
+// consuming a token immediately if one is already available.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+	b.tokens += elapsed * b.ratePerSec
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	deficit := 1 - b.tokens
+	b.tokens = 0
+	return time.Duration(deficit/b.ratePerSec*1000) * time.Millisecond
+}
+
+// rateLimiter enforces both a global outbound rate and a per-destination
+// callsign rate, matching APRS-IS flood guidelines.
+type rateLimiter struct {
+	mu       sync.Mutex
+	global   *tokenBucket
+	perCall  map[string]*tokenBucket
+	callRate float64
+}
+
+func newRateLimiter(globalRate, perCallsignRate float64) *rateLimiter {
+	return &rateLimiter{
+		global:   newTokenBucket(globalRate),
+		perCall:  make(map[string]*tokenBucket),
+		callRate: perCallsignRate,
+	}
+}
+
+// Wait blocks until both the global and per-callsign buckets allow another send.
+func (r *rateLimiter) Wait(callsign string) {
+	r.WaitAt(callsign, r.callRate)
+}
+
+// WaitAt is like Wait but, for a callsign seen for the first time, creates
+// its bucket at rate instead of the default per-callsign rate. This lets a
+// path rule's rate_limit_per_sec override the default for matching
+// callsigns without needing a second limiter.
+func (r *rateLimiter) WaitAt(callsign string, rate float64) {
+	r.mu.Lock()
+	bucket, ok := r.perCall[callsign]
+	if !ok {
+		if rate <= 0 {
+			rate = r.callRate
+		}
+		bucket = newTokenBucket(rate)
+		r.perCall[callsign] = bucket
+	}
+	r.mu.Unlock()
+
+	bucket.wait()
+	r.global.wait()
+}
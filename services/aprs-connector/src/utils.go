@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"math/rand"
 	"strings"
 	"time"
 
@@ -37,6 +38,15 @@ func setupLogger(config *Config) *logrus.Logger {
 		})
 	}
 
+	// Configure the primary sink (stdout/stderr/file) and attach any
+	// additional sinks (syslog/http) as hooks so they can be combined.
+	if writer, rotator := buildLogWriter(config.Logging); rotator != nil {
+		logger.SetOutput(rotator)
+	} else {
+		logger.SetOutput(writer)
+	}
+	attachSinkHooks(logger, config.Logging)
+
 	return logger
 }
 
@@ -79,30 +89,81 @@ func truncateForAPRS(content string, maxLength int) string {
 	return content[:maxLength-3] + "..."
 }
 
-// retryWithBackoff executes a function with exponential backoff
-func retryWithBackoff(fn func() error, maxRetries int, initialDelay time.Duration, logger *logrus.Logger) error {
-	var err error
-	delay := initialDelay
+// BackoffStrategy selects how newBackoffSequence spaces out successive delays.
+type BackoffStrategy string
 
-	for i := 0; i < maxRetries; i++ {
-		err = fn()
-		if err == nil {
-			return nil
-		}
+const (
+	BackoffExponential BackoffStrategy = "exponential"
+	BackoffFibonacci   BackoffStrategy = "fibonacci"
+	BackoffConstant    BackoffStrategy = "constant"
+)
+
+// RetryPolicy bounds how newBackoffSequence (and callers built around it,
+// like runService's reconnect loop) space out and eventually give up on
+// retries.
+type RetryPolicy struct {
+	MaxRetries      int
+	InitialDelay    time.Duration
+	MaxDelay        time.Duration   // caps any single delay; 0 means unbounded
+	TotalTimeout    time.Duration   // abort if elapsed+next delay would exceed this; 0 means unbounded
+	Jitter          float64         // 0.0-1.0, applied symmetrically to each delay
+	BackoffStrategy BackoffStrategy // defaults to exponential
+}
+
+// newBackoffSequence returns a closure yielding successive delays per the
+// policy's strategy (doubling, Fibonacci, or constant), each capped at
+// MaxDelay and randomized by +/-Jitter.
+func newBackoffSequence(policy RetryPolicy) func() time.Duration {
+	strategy := policy.BackoffStrategy
+	if strategy == "" {
+		strategy = BackoffExponential
+	}
+
+	initial := policy.InitialDelay
+	if initial <= 0 {
+		initial = time.Second
+	}
 
-		if i < maxRetries-1 {
-			logger.WithFields(logrus.Fields{
-				"attempt": i + 1,
-				"delay":   delay,
-				"error":   err,
-			}).Warn("Operation failed, retrying")
+	exponential := initial
+	fibA, fibB := time.Second, time.Second
+
+	return func() time.Duration {
+		var delay time.Duration
+		switch strategy {
+		case BackoffFibonacci:
+			delay = fibA
+			fibA, fibB = fibB, fibA+fibB
+		case BackoffConstant:
+			delay = initial
+		default:
+			delay = exponential
+			exponential *= 2
+		}
 
-			time.Sleep(delay)
-			delay *= 2 // Exponential backoff
+		if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+			delay = policy.MaxDelay
 		}
+
+		return applyJitter(delay, policy.Jitter)
+	}
+}
+
+// applyJitter randomizes d by +/-factor (0.0-1.0), never returning a
+// negative duration.
+func applyJitter(d time.Duration, factor float64) time.Duration {
+	if factor <= 0 {
+		return d
+	}
+	if factor > 1 {
+		factor = 1
 	}
 
-	return fmt.Errorf("operation failed after %d attempts: %w", maxRetries, err)
+	delta := float64(d) * factor
+	jittered := float64(d) + (rand.Float64()*2-1)*delta
+	if jittered < 0 {
+		jittered = 0
+	}
+	return time.Duration(jittered)
 }
 
 // isValidMessageContent checks if message content is valid for transmission
@@ -159,6 +220,18 @@ func formatDuration(d time.Duration) string {
 	}
 }
 
+// filterAllowed returns the items in routes that also appear in allowed,
+// preserving routes' order.
+func filterAllowed(routes, allowed []string) []string {
+	filtered := make([]string, 0, len(routes))
+	for _, r := range routes {
+		if containsFold(allowed, r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
 // getSystemInfo returns basic system information
 func getSystemInfo() map[string]interface{} {
 	return map[string]interface{}{